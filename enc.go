@@ -0,0 +1,313 @@
+package scru128
+
+import (
+	"encoding/ascii85"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// Creates a SCRU128 ID object from a textual representation in one of the
+// following forms, dispatching by the length of `s`:
+//
+//   - 25 digits: the canonical Base36 representation (see [Parse]).
+//   - 26 digits: Crockford's Base32 representation (see
+//     [ParseBase32Crockford]).
+//
+// This function returns an error for any other input. To continue generating
+// IDs after a parsed one, pass it to [Generator.GenerateAfter].
+func ParseFlexible(s string) (id Id, err error) {
+	switch len(s) {
+	case StringLen:
+		return Parse(s)
+	case 26:
+		return ParseBase32Crockford(s)
+	default:
+		return Id{}, newParseError(
+			fmt.Errorf("%w: %d bytes (expected 25 or 26)", ErrInvalidLength, len(s)))
+	}
+}
+
+// Creates a SCRU128 ID object from a textual representation in one of the
+// following forms, dispatching by the length and prefix of `s`:
+//
+//   - 25 digits: the canonical Base36 representation (see [Parse]).
+//   - 32 digits: the hexadecimal representation of the 128-bit unsigned
+//     integer, e.g., "017fa1918bd562c17c1e2cbcbe430b4a".
+//   - "0x" or "0X" followed by 32 hexadecimal digits, e.g.,
+//     "0x017fa1918bd562c17c1e2cbcbe430b4a".
+//
+// The input is case-insensitive, and the hexadecimal forms must be zero-padded
+// to 32 digits. This function returns an error for any other input. Use
+// [Parse] to accept the canonical form only.
+func ParseAny(s string) (id Id, err error) {
+	switch len(s) {
+	case StringLen:
+		return Parse(s)
+	case 32:
+		err = id.decodeFixedRadix([]byte(s), hexDecodeMap, 16, 32)
+		return
+	case 34:
+		if s[0] != '0' {
+			return Id{}, newParseError(&InvalidDigitError{Pos: 0, Char: s[0]})
+		} else if s[1] != 'x' && s[1] != 'X' {
+			return Id{}, newParseError(&InvalidDigitError{Pos: 1, Char: s[1]})
+		}
+		err = id.decodeFixedRadix([]byte(s[2:]), hexDecodeMap, 16, 32)
+		var digitErr *InvalidDigitError
+		if errors.As(err, &digitErr) {
+			digitErr.Pos += 2 // report position in `s`
+		}
+		return
+	default:
+		return Id{}, newParseError(fmt.Errorf(
+			"%w: %d bytes (expected 25, 32, or 34)", ErrInvalidLength, len(s)))
+	}
+}
+
+// An O(1) map from ASCII code points to case-insensitive hexadecimal digit
+// values.
+var hexDecodeMap = func() (m [256]byte) {
+	m = newDecodeMap([]byte("0123456789abcdef"))
+	for i, e := range "ABCDEF" {
+		m[e] = byte(10 + i)
+	}
+	return
+}()
+
+// Digit characters used in Crockford's Base32 notation.
+var crockfordDigits = []byte("0123456789ABCDEFGHJKMNPQRSTVWXYZ")
+
+// An O(1) map from ASCII code points to Crockford's Base32 digit values.
+var crockfordDecodeMap = func() (m [256]byte) {
+	m = newDecodeMap(crockfordDigits)
+	for i, e := range crockfordDigits {
+		if e >= 'A' {
+			m[e-'A'+'a'] = byte(i)
+		}
+	}
+	m['O'], m['o'] = 0, 0
+	m['I'], m['i'], m['L'], m['l'] = 1, 1, 1, 1
+	return
+}()
+
+// Returns the 26-digit representation in Crockford's Base32, which excludes
+// the letters I, L, O, and U to reduce transcription errors.
+//
+// The result consists of digits and uppercase letters and is sortable, but it
+// is not part of the SCRU128 specification.
+func (bs Id) Base32Crockford() string {
+	return string(bs.encodeFixedRadix(crockfordDigits, 26))
+}
+
+// Creates a SCRU128 ID object from a 26-digit representation in Crockford's
+// Base32.
+//
+// The input is case-insensitive, and the letters O, I, and L are read as 0, 1,
+// and 1, respectively, as defined by Crockford's Base32.
+func ParseBase32Crockford(s string) (id Id, err error) {
+	err = id.decodeFixedRadix([]byte(s), crockfordDecodeMap, 32, 26)
+	return
+}
+
+// Digit characters used in the Base62 notation, arranged in the ASCII order so
+// that the fixed-width representation is sortable.
+var base62Digits = []byte(
+	"0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+
+// An O(1) map from ASCII code points to Base62 digit values.
+var base62DecodeMap = newDecodeMap(base62Digits)
+
+// Returns the 22-digit Base62 representation, which consists only of URL-safe
+// characters (0-9, A-Z, and a-z).
+//
+// The Base62 representation is case-sensitive and is sortable in the ASCII
+// (byte-wise) order, but it is not part of the SCRU128 specification.
+func (bs Id) Base62() string {
+	return string(bs.encodeFixedRadix(base62Digits, 22))
+}
+
+// Creates a SCRU128 ID object from a 22-digit Base62 representation.
+//
+// See [Id.Base62] for the format.
+func ParseBase62(s string) (id Id, err error) {
+	err = id.decodeFixedRadix([]byte(s), base62DecodeMap, 62, 22)
+	return
+}
+
+// Digit characters used in the decimal notation.
+var decimalDigits = []byte("0123456789")
+
+// An O(1) map from ASCII code points to decimal digit values.
+var decimalDecodeMap = newDecodeMap(decimalDigits)
+
+// Returns the 128-bit unsigned integer as a 39-digit zero-padded decimal
+// representation, e.g., for legacy systems that sort IDs lexicographically as
+// decimal strings.
+//
+// Because the width is fixed, the lexicographic order of the representation
+// matches the numeric order of IDs. This format is not part of the SCRU128
+// specification.
+func (bs Id) DecimalString() string {
+	return string(bs.encodeFixedRadix(decimalDigits, 39))
+}
+
+// Creates a SCRU128 ID object from a 39-digit zero-padded decimal
+// representation.
+//
+// See [Id.DecimalString] for the format.
+func ParseDecimal(s string) (id Id, err error) {
+	err = id.decodeFixedRadix([]byte(s), decimalDecodeMap, 10, 39)
+	return
+}
+
+// Returns the Ascii85 representation of the 16-byte big-endian byte array,
+// which is 20 characters long, e.g., for space-constrained contexts such as QR
+// codes.
+//
+// The representation is encoded by encoding/ascii85, which abbreviates each
+// all-zero four-byte group into "z"; hence, IDs containing such groups, e.g.,
+// [Nil], are encoded into fewer characters. Note that the Ascii85
+// representation is NOT sortable and contains characters such as quotes and
+// backslashes that need escaping in many contexts. This format is not part of
+// the SCRU128 specification.
+func (bs Id) Ascii85() string {
+	var buffer [20]byte
+	n := ascii85.Encode(buffer[:], bs[:])
+	return string(buffer[:n])
+}
+
+// Creates a SCRU128 ID object from an Ascii85 representation produced by
+// [Id.Ascii85].
+//
+// This function accepts the representation that [Id.Ascii85] produces only; it
+// rejects whitespace, delimiters such as "<~" and "~>", and non-canonical
+// forms such as "!!!!!" in place of "z".
+func ParseAscii85(s string) (id Id, err error) {
+	if len(s) > 20 {
+		return Id{}, newParseError(fmt.Errorf(
+			"%w: %d bytes (expected up to 20)", ErrInvalidLength, len(s)))
+	}
+	buffer := make([]byte, 4*len(s))
+	n, _, err := ascii85.Decode(buffer, []byte(s), true)
+	if err != nil {
+		return Id{}, newParseError(err)
+	} else if n != 16 {
+		return Id{}, newParseError(fmt.Errorf(
+			"%w: decoded into %d bytes (expected 16)", ErrInvalidLength, n))
+	}
+	copy(id[:], buffer)
+	if id.Ascii85() != s {
+		return Id{}, newParseError(fmt.Errorf("non-canonical Ascii85 representation"))
+	}
+	return id, nil
+}
+
+// Creates a map from ASCII code points to digit values for `digits`.
+func newDecodeMap(digits []byte) (m [256]byte) {
+	for i := range m {
+		m[i] = 0xff
+	}
+	for i, e := range digits {
+		m[e] = byte(i)
+	}
+	return
+}
+
+// Encodes the 128-bit value into a `width`-digit big-endian representation in
+// the radix of len(digits).
+func (bs Id) encodeFixedRadix(digits []byte, width int) []byte {
+	hi, lo := bs.Uint64Pair()
+	radix := uint64(len(digits))
+	text := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		var rem uint64
+		hi, rem = bits.Div64(0, hi, radix)
+		lo, rem = bits.Div64(rem, lo, radix)
+		text[i] = digits[rem]
+	}
+	return text
+}
+
+// Decodes a `width`-digit big-endian representation in `radix` into the
+// receiver, translating digit characters through `decodeMap`.
+func (bs *Id) decodeFixedRadix(
+	text []byte,
+	decodeMap [256]byte,
+	radix uint64,
+	width int,
+) error {
+	if len(text) != width {
+		return newParseError(fmt.Errorf(
+			"%w: %d bytes (expected %d)", ErrInvalidLength, len(text), width))
+	}
+
+	var hi, lo uint64
+	for i, e := range text {
+		n := decodeMap[e]
+		if n == 0xff {
+			return newParseError(&InvalidDigitError{Pos: i, Char: e})
+		}
+
+		// (hi, lo) = (hi, lo) * radix + n
+		hiHi, hiLo := bits.Mul64(hi, radix)
+		loHi, loLo := bits.Mul64(lo, radix)
+		var carry uint64
+		lo, carry = bits.Add64(loLo, uint64(n), 0)
+		hi, carry = bits.Add64(hiLo, loHi+carry, 0)
+		if hiHi != 0 || carry != 0 {
+			return newParseError(ErrOutOfRange)
+		}
+	}
+
+	*bs = FromUint64Pair(hi, lo)
+	return nil
+}
+
+// Returns the 26-digit representation that consists of the 25-digit canonical
+// representation followed by a Base36 check digit.
+//
+// The check digit is computed over the 25 Base36 digits (hence over the 128-bit
+// value) using the ISO/IEC 7064 MOD 37,36 hybrid system, which detects all
+// single-digit substitution errors and most transpositions of adjacent digits
+// that occur when IDs are transcribed by humans.
+//
+// This is a non-standard extension of this package, not part of the SCRU128
+// specification; the result is not accepted by [Parse].
+func (bs Id) StringWithCheck() string {
+	text, _ := bs.MarshalText()
+	return string(append(text, digits[checkDigit(text)]))
+}
+
+// Creates a SCRU128 ID object from a 26-digit representation produced by
+// [Id.StringWithCheck], returning an error if the check digit does not match.
+func ParseWithCheck(s string) (id Id, err error) {
+	if len(s) != 26 {
+		return Id{}, newParseError(
+			fmt.Errorf("%w: %d bytes (expected 26)", ErrInvalidLength, len(s)))
+	}
+	text := []byte(s)
+	if err = id.UnmarshalText(text[:StringLen]); err != nil {
+		return Id{}, err
+	}
+	if decodeMap[text[StringLen]] == 0xff {
+		return Id{}, newParseError(&InvalidDigitError{Pos: StringLen, Char: text[StringLen]})
+	} else if decodeMap[text[StringLen]] != checkDigit(text[:StringLen]) {
+		return Id{}, newParseError(fmt.Errorf("check digit mismatch"))
+	}
+	return
+}
+
+// Computes the ISO/IEC 7064 MOD 37,36 check digit value of valid Base36 digit
+// characters.
+func checkDigit(text []byte) byte {
+	p := 36
+	for _, e := range text {
+		s := (p + int(decodeMap[e])) % 36
+		if s == 0 {
+			s = 36
+		}
+		p = (s * 2) % 37
+	}
+	return byte((37 - p) % 36)
+}
@@ -0,0 +1,435 @@
+package scru128
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Parses canonical and Crockford's Base32 forms
+func TestParseFlexible(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{"036z8puq4tsxsigk6o19y164q", "036z8puq4tsxsigk6o19y164q"},
+		{"036Z8PUQ4TSXSIGK6O19Y164Q", "036z8puq4tsxsigk6o19y164q"},
+		{"01FYGS32YNCB0QR7HCQJZ462TA", "036z8puq4tsxsigk6o19y164q"},
+		{"01fygs32yncb0qr7hcqjz462ta", "036z8puq4tsxsigk6o19y164q"},
+		{"o1FYGS32YNCBOQR7HCQJZ462TA", "036z8puq4tsxsigk6o19y164q"},
+		{"00000000000000000000000000", "0000000000000000000000000"},
+		{"0000000000000000000000000I", "0000000000000000000000001"},
+		{"0000000000000000000000000l", "0000000000000000000000001"},
+		{"7ZZZZZZZZZZZZZZZZZZZZZZZZZ", "f5lxx1zz5pnorynqglhzmsp33"},
+	}
+
+	for _, e := range cases {
+		x, err := ParseFlexible(e.input)
+		if err != nil || x.String() != e.expected {
+			t.Errorf("%s: got %s, %v", e.input, x, err)
+		}
+	}
+}
+
+// Rejects inputs in neither canonical nor Crockford's Base32 form
+func TestParseFlexibleValidation(t *testing.T) {
+	cases := []string{
+		"",
+		"036z8puq4tsxsigk6o19y164",
+		"01FYGS32YNCB0QR7HCQJZ462TA0",
+		"zzzzzzzzzzzzzzzzzzzzzzzzz",
+		"80000000000000000000000000",
+		"ZZZZZZZZZZZZZZZZZZZZZZZZZZ",
+		"01FYGS32YNCB0QR7HCQJZ462TU",
+		"01FYGS32YNCB0QR7HCQJZ462T-",
+		"01FYGS32YNCB0QR7HCQJZ46 TA",
+		"01FYGS32YNCB0QR7HCQJZ4漢",
+	}
+
+	for _, e := range cases {
+		if _, err := ParseFlexible(e); err == nil {
+			t.Errorf("accepted %q", e)
+		}
+	}
+}
+
+// Parses canonical, hexadecimal, and 0x-prefixed hexadecimal forms
+func TestParseAny(t *testing.T) {
+	expected := FromUint64Pair(0x017f_a191_8bd5_62c1, 0x7c1e_2cbc_be43_0b4a)
+	cases := []string{
+		"036z8puq4tsxsigk6o19y164q",
+		"036Z8PUQ4TSXSIGK6O19Y164Q",
+		"017fa1918bd562c17c1e2cbcbe430b4a",
+		"017FA1918BD562C17C1E2CBCBE430B4A",
+		"0x017fa1918bd562c17c1e2cbcbe430b4a",
+		"0X017FA1918bd562c17c1e2cbcbe430b4a",
+	}
+	for _, e := range cases {
+		if x, err := ParseAny(e); err != nil || x != expected {
+			t.Errorf("%s: got %s, %v", e, x, err)
+		}
+	}
+
+	g := NewGenerator()
+	for i := 0; i < 1_000; i++ {
+		e, _ := g.Generate()
+		hi, lo := e.Uint64Pair()
+		h := fmt.Sprintf("%016x%016x", hi, lo)
+		for _, s := range []string{e.String(), h, "0x" + h} {
+			if x, err := ParseAny(s); err != nil || x != e {
+				t.Fail()
+			}
+		}
+	}
+	if x, err := ParseAny("0x" + strings.Repeat("f", 32)); err != nil || x != Max {
+		t.Fail()
+	}
+}
+
+// Rejects inputs in none of the accepted forms
+func TestParseAnyValidation(t *testing.T) {
+	cases := []struct {
+		input string
+		pos   int
+	}{
+		{"", -1},
+		{"0x", -1},
+		{"017fa1918bd562c17c1e2cbcbe430b4", -1},
+		{"0x017fa1918bd562c17c1e2cbcbe430b4", -1},
+		{"0x017fa1918bd562c17c1e2cbcbe430b4a0", -1},
+		{"01FYGS32YNCB0QR7HCQJZ462TA", -1},
+		{"zzzzzzzzzzzzzzzzzzzzzzzzz", -1},
+		{"017fa1918bd562c17c1e2cbcbe430b4g", 31},
+		{"017fa1918bd562c1-7c1e2cbcbe430b4", 16},
+		{"0x017fa1918bd562c17c1e2cbcbe430b4g", 33},
+		{"0x0x7fa1918bd562c17c1e2cbcbe430b4a", 3},
+		{"00017fa1918bd562c17c1e2cbcbe430b4a", 1},
+		{"1x017fa1918bd562c17c1e2cbcbe430b4a", 0},
+		{"0x017fa1918bd562c17c1e2cbcbe430漢", 31},
+	}
+
+	for _, e := range cases {
+		_, err := ParseAny(e.input)
+		if err == nil {
+			t.Errorf("accepted %q", e.input)
+		}
+		var digitErr *InvalidDigitError
+		if e.pos >= 0 && (!errors.As(err, &digitErr) || digitErr.Pos != e.pos) {
+			t.Errorf("%q: got %v, want error at %d", e.input, err, e.pos)
+		}
+	}
+}
+
+// Encodes and decodes Crockford's Base32 representation
+func TestBase32Crockford(t *testing.T) {
+	cases := []struct {
+		id       Id
+		expected string
+	}{
+		{Id{}, "00000000000000000000000000"},
+		{FromFields(0, 0, 0, 1), "00000000000000000000000001"},
+		{FromFields(0, 0, 0, 32), "00000000000000000000000010"},
+		{FromUint64Pair(0x017f_a191_8bd5_62c1, 0x7c1e_2cbc_be43_0b4a), "01FYGS32YNCB0QR7HCQJZ462TA"},
+		{FromUint64Pair(1<<64-1, 1<<64-1), "7ZZZZZZZZZZZZZZZZZZZZZZZZZ"},
+	}
+
+	for _, e := range cases {
+		if e.id.Base32Crockford() != e.expected {
+			t.Errorf("got %s, want %s", e.id.Base32Crockford(), e.expected)
+		}
+		if x, err := ParseBase32Crockford(e.expected); err != nil || x != e.id {
+			t.Fail()
+		}
+		if x, err := ParseBase32Crockford(strings.ToLower(e.expected)); err != nil || x != e.id {
+			t.Fail()
+		}
+	}
+
+	// substitutions
+	for _, e := range []string{
+		"O1FYGS32YNCB0QR7HCQJZ462TA",
+		"o1FYGS32YNCBoQR7HCQJZ462TA",
+		"0IFYGS32YNCB0QR7HCQJZ462TA",
+		"0iFYGS32YNCB0QR7HCQJZ462TA",
+		"0LFYGS32YNCB0QR7HCQJZ462TA",
+		"0lFYGS32YNCB0QR7HCQJZ462TA",
+	} {
+		x, err := ParseBase32Crockford(e)
+		if err != nil || x.Base32Crockford() != "01FYGS32YNCB0QR7HCQJZ462TA" {
+			t.Fail()
+		}
+	}
+
+	g := NewGenerator()
+	prev := Id{}.Base32Crockford()
+	for i := 0; i < 1_000; i++ {
+		e, _ := g.Generate()
+		s := e.Base32Crockford()
+		if len(s) != 26 || s <= prev || strings.ContainsAny(s, "ILOU") {
+			t.Fail()
+		}
+		if x, err := ParseBase32Crockford(s); err != nil || x != e {
+			t.Fail()
+		}
+		prev = s
+	}
+}
+
+// Rejects invalid Crockford's Base32 representation
+func TestParseBase32CrockfordValidation(t *testing.T) {
+	cases := []string{
+		"",
+		"01FYGS32YNCB0QR7HCQJZ462T",
+		"01FYGS32YNCB0QR7HCQJZ462TA0",
+		"80000000000000000000000000",
+		"01FYGS32YNCB0QR7HCQJZ462TU",
+		"01FYGS32YNCB0QR7HCQJZ462Tu",
+		"01FYGS32YNCB0QR7HCQJZ462T-",
+		"01FYGS32YNCB0QR7HCQJZ462T*",
+		"01FYGS32YNCB0QR7HCQJZ462T=",
+		"01FYGS32YNCB0QR7HCQJZ4漢A",
+	}
+
+	for _, e := range cases {
+		if _, err := ParseBase32Crockford(e); err == nil {
+			t.Errorf("accepted %q", e)
+		}
+	}
+}
+
+// Encodes and decodes Base62 representation
+func TestBase62(t *testing.T) {
+	cases := []struct {
+		id       Id
+		expected string
+	}{
+		{Id{}, "0000000000000000000000"},
+		{FromFields(0, 0, 0, 1), "0000000000000000000001"},
+		{FromFields(0, 0, 0, 62), "0000000000000000000010"},
+		{FromUint64Pair(0x017f_a191_8bd5_62c1, 0x7c1e_2cbc_be43_0b4a), "02pJpkiyTfgXo6UhgoJnlS"},
+		{FromUint64Pair(1<<64-1, 1<<64-1), "7n42DGM5Tflk9n8mt7Fhc7"},
+	}
+
+	for _, e := range cases {
+		if e.id.Base62() != e.expected {
+			t.Errorf("got %s, want %s", e.id.Base62(), e.expected)
+		}
+		if x, err := ParseBase62(e.expected); err != nil || x != e.id {
+			t.Fail()
+		}
+	}
+
+	g := NewGenerator()
+	prev := Id{}.Base62()
+	for i := 0; i < 1_000; i++ {
+		e, _ := g.Generate()
+		s := e.Base62()
+		if len(s) != 22 || s <= prev {
+			t.Fail()
+		}
+		if x, err := ParseBase62(s); err != nil || x != e {
+			t.Fail()
+		}
+		prev = s
+	}
+}
+
+// Rejects invalid Base62 representation
+func TestParseBase62Validation(t *testing.T) {
+	cases := []string{
+		"",
+		"000000000000000000000",
+		"00000000000000000000000",
+		"7n42DGM5Tflk9n8mt7Fhc8",
+		"zzzzzzzzzzzzzzzzzzzzzz",
+		"02pJpkiyTfgXo6Uhgo-nlS",
+		"02pJpkiyTfgXo6Uhgo_nlS",
+		"02pJpkiyTfgXo6Uhgo nlS",
+		"02pJpkiyTfgXo6Uhg漢S",
+	}
+
+	for _, e := range cases {
+		if _, err := ParseBase62(e); err == nil {
+			t.Errorf("accepted %q", e)
+		}
+	}
+}
+
+// Encodes and decodes fixed-width decimal representation
+func TestDecimalString(t *testing.T) {
+	cases := []struct {
+		id       Id
+		expected string
+	}{
+		{Id{}, "000000000000000000000000000000000000000"},
+		{FromFields(0, 0, 0, 1), "000000000000000000000000000000000000001"},
+		{FromFields(0, 0, 0, 10), "000000000000000000000000000000000000010"},
+		{FromUint64Pair(1, 0), "000000000000000000018446744073709551616"},
+		{FromUint64Pair(0x017f_a191_8bd5_62c1, 0x7c1e_2cbc_be43_0b4a), "001991926696124975394819829782714452810"},
+		{FromUint64Pair(1<<64-1, 1<<64-1), "340282366920938463463374607431768211455"},
+	}
+
+	for _, e := range cases {
+		if e.id.DecimalString() != e.expected {
+			t.Errorf("got %s, want %s", e.id.DecimalString(), e.expected)
+		}
+		if x, err := ParseDecimal(e.expected); err != nil || x != e.id {
+			t.Fail()
+		}
+	}
+
+	g := NewGenerator()
+	prev := Id{}.DecimalString()
+	for i := 0; i < 1_000; i++ {
+		e, _ := g.Generate()
+		s := e.DecimalString()
+		if len(s) != 39 || s <= prev {
+			t.Fail()
+		}
+		if x, err := ParseDecimal(s); err != nil || x != e {
+			t.Fail()
+		}
+		prev = s
+	}
+}
+
+// Rejects invalid decimal representation
+func TestParseDecimalValidation(t *testing.T) {
+	cases := []string{
+		"",
+		"00000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000",
+		"340282366920938463463374607431768211456",
+		"999999999999999999999999999999999999999",
+		"00199192669612497539481982978271445281a",
+		"-01991926696124975394819829782714452810",
+		"00199192669612497539481982978271445281 ",
+		"0019919266961249753948198297827144528漢",
+	}
+
+	for _, e := range cases {
+		if _, err := ParseDecimal(e); err == nil {
+			t.Errorf("accepted %q", e)
+		}
+	}
+}
+
+// Encodes and decodes Ascii85 representation
+func TestAscii85(t *testing.T) {
+	cases := []struct {
+		id       Id
+		expected string
+	}{
+		{Id{}, "zzzz"},
+		{FromFields(0, 0, 0, 1), "zzz!!!!\""},
+		{FromUint64Pair(0x017f_a191_8bd5_62c1, 0x7c1e_2cbc_be43_0b4a), "!Ipf*Mq):QHlaiO^-`9#"},
+		{FromUint64Pair(1<<64-1, 1<<64-1), "s8W-!s8W-!s8W-!s8W-!"},
+	}
+
+	for _, e := range cases {
+		if e.id.Ascii85() != e.expected {
+			t.Errorf("got %s, want %s", e.id.Ascii85(), e.expected)
+		}
+		if x, err := ParseAscii85(e.expected); err != nil || x != e.id {
+			t.Fail()
+		}
+	}
+
+	g := NewGenerator()
+	for i := 0; i < 1_000; i++ {
+		e, _ := g.Generate()
+		s := e.Ascii85()
+		if len(s) != 20 {
+			t.Fail()
+		}
+		if x, err := ParseAscii85(s); err != nil || x != e {
+			t.Fail()
+		}
+	}
+}
+
+// Rejects invalid Ascii85 representation
+func TestParseAscii85Validation(t *testing.T) {
+	cases := []string{
+		"",
+		"zzz",
+		"zzzzz",
+		"!Ipf*Mq):QHlaiO^-`9",
+		"!Ipf*Mq):QHlaiO^-`9#!",
+		"!Ipf*Mq):QHlaiO^-`9v",
+		"!Ipf*Mq):QHlaiO^ -`9#",
+		"<~!Ipf*Mq):QHlaiO^-`9#~>",
+		"!!!!!zzz",
+		"s8W-!s8W-!s8W-!s8W-\"",
+		"!Ipf*Mq):QHlaiO^-`漢",
+	}
+
+	for _, e := range cases {
+		if _, err := ParseAscii85(e); err == nil {
+			t.Errorf("accepted %q", e)
+		}
+	}
+}
+
+// Appends and validates check digit
+func TestStringWithCheck(t *testing.T) {
+	transpositions, detected := 0, 0
+	g := NewGenerator()
+	for i := 0; i < 1_000; i++ {
+		e, _ := g.Generate()
+		s := e.StringWithCheck()
+		if len(s) != 26 || s[:25] != e.String() {
+			t.Fail()
+		}
+		if x, err := ParseWithCheck(s); err != nil || x != e {
+			t.Fail()
+		}
+		if x, err := ParseWithCheck(strings.ToUpper(s)); err != nil || x != e {
+			t.Fail()
+		}
+		if _, err := Parse(s); err == nil {
+			t.Fail()
+		}
+
+		// detects single-digit substitution errors
+		for j := 0; j < len(s); j++ {
+			for _, c := range []byte("0az") {
+				if c == s[j] {
+					continue
+				}
+				corrupted := s[:j] + string(c) + s[j+1:]
+				if x, err := ParseWithCheck(corrupted); err == nil {
+					t.Errorf("%s: accepted %s as %s", s, corrupted, x)
+				}
+			}
+		}
+
+		// detects most adjacent transpositions
+		for j := 0; j < len(s)-1; j++ {
+			if s[j] == s[j+1] {
+				continue
+			}
+			transposed := s[:j] + s[j+1:j+2] + s[j:j+1] + s[j+2:]
+			transpositions++
+			if _, err := ParseWithCheck(transposed); err != nil {
+				detected++
+			}
+		}
+	}
+	if float64(detected) < 0.95*float64(transpositions) {
+		t.Fail()
+	}
+
+	for _, e := range []string{
+		"",
+		"036z8puq4tsxsigk6o19y164q",
+		"036z8puq4tsxsigk6o19y164q00",
+		"036z8puq4tsxsigk6o19y164q-",
+		"zzzzzzzzzzzzzzzzzzzzzzzzz0",
+	} {
+		if _, err := ParseWithCheck(e); err == nil {
+			t.Errorf("accepted %q", e)
+		}
+	}
+}
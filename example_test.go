@@ -0,0 +1,53 @@
+package scru128_test
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"sort"
+
+	"github.com/scru128/go-scru128/v3"
+)
+
+// Inserts `v` into the sorted slice `s`, keeping it sorted by `cmp`.
+func insertSorted[T any](s []T, v T, cmp func(a, b T) int) []T {
+	i := sort.Search(len(s), func(i int) bool { return cmp(s[i], v) > 0 })
+	s = append(s, v)
+	copy(s[i+1:], s[i:])
+	s[i] = v
+	return s
+}
+
+func ExampleCompare() {
+	var ids []scru128.Id
+	for _, e := range []string{
+		"036z8puq4tsxsigk6o19y164q",
+		"036z8puq54qny1vq3hcbrkweb",
+		"036z8puq4tsxsigk6o19y164r",
+	} {
+		id, _ := scru128.Parse(e)
+		ids = insertSorted(ids, id, scru128.Compare)
+	}
+
+	for _, e := range ids {
+		fmt.Println(e)
+	}
+	// Output:
+	// 036z8puq4tsxsigk6o19y164q
+	// 036z8puq4tsxsigk6o19y164r
+	// 036z8puq54qny1vq3hcbrkweb
+}
+
+// html/template embeds IDs without helpers: the Base36 digits need no escaping
+// in HTML text, attributes, or URLs, and JavaScript contexts receive a quoted
+// string through Id.MarshalJSON.
+func ExampleId_htmlTemplate() {
+	id, _ := scru128.Parse("036z8puq4tsxsigk6o19y164q")
+	tmpl := template.Must(template.New("").Parse(
+		`<a href="/users/{{.}}" data-id="{{.}}">{{.}}</a>` + "\n" +
+			`<script>const id = {{.}};</script>` + "\n"))
+	tmpl.Execute(os.Stdout, id)
+	// Output:
+	// <a href="/users/036z8puq4tsxsigk6o19y164q" data-id="036z8puq4tsxsigk6o19y164q">036z8puq4tsxsigk6o19y164q</a>
+	// <script>const id = "036z8puq4tsxsigk6o19y164q";</script>
+}
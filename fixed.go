@@ -0,0 +1,46 @@
+package scru128
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Represents a stand-in for [Generator] that returns predetermined IDs in
+// order, e.g., IDs injected through environment variables or configuration in
+// integration tests, without mocking the random number generator.
+//
+// This structure must be instantiated by [NewFixedGenerator].
+type FixedGenerator struct {
+	ids []Id
+
+	lock sync.Mutex
+}
+
+// Creates a fixed generator object that returns `ids` in the order given.
+//
+// The generator copies `ids`, so the caller may modify the slice afterwards.
+func NewFixedGenerator(ids ...Id) *FixedGenerator {
+	return &FixedGenerator{ids: append([]Id(nil), ids...)}
+}
+
+// Returns the next ID of the predetermined sequence.
+//
+// This method is thread-safe; concurrent calls receive distinct elements of
+// the sequence.
+//
+// This method returns the [ErrFixedGeneratorExhausted] err once all the IDs
+// have been returned.
+func (g *FixedGenerator) Generate() (id Id, err error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if len(g.ids) == 0 {
+		return Id{}, ErrFixedGeneratorExhausted
+	}
+	id, g.ids = g.ids[0], g.ids[1:]
+	return id, nil
+}
+
+// The error value returned by [FixedGenerator.Generate] when the generator has
+// returned all the predetermined IDs.
+var ErrFixedGeneratorExhausted = fmt.Errorf(
+	"scru128.FixedGenerator: no more IDs to return")
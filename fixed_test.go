@@ -0,0 +1,30 @@
+package scru128
+
+import "testing"
+
+// Returns queued IDs in order and errors after the last
+func TestFixedGenerator(t *testing.T) {
+	ids := []Id{
+		FromFields(0x0123_4567_89ab, 0, 0, 0),
+		Max,
+		Nil,
+		FromFields(0x0123_4567_89ab, 0, 0, 0),
+	}
+	g := NewFixedGenerator(ids...)
+	ids[0] = Nil // does not affect generator
+	for i, e := range ids {
+		x, err := g.Generate()
+		if err != nil || (i > 0 && x != e) || (i == 0 && x == e) {
+			t.Fail()
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if x, err := g.Generate(); err != ErrFixedGeneratorExhausted || x != Nil {
+			t.Fail()
+		}
+	}
+
+	if _, err := NewFixedGenerator().Generate(); err != ErrFixedGeneratorExhausted {
+		t.Fail()
+	}
+}
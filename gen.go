@@ -3,8 +3,11 @@ package scru128
 import (
 	"bufio"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"io"
+	"math/bits"
+	"os"
 	"sync"
 	"time"
 )
@@ -13,7 +16,7 @@ import (
 // and other internal states.
 //
 // This structure must be instantiated by one of the dedicated constructors:
-// [NewGenerator] or [NewGeneratorWithRng].
+// [NewGenerator], [NewGeneratorWithRng], or [NewGeneratorChecked].
 //
 // # Generator functions
 //
@@ -46,10 +49,38 @@ type Generator struct {
 	// The timestamp at the last renewal of counter_hi field.
 	tsCounterHi uint64
 
+	// The interval in milliseconds at which counter_hi field is renewed.
+	counterHiRenewalInterval uint64
+
 	// The random number generator used by the generator.
 	rng io.Reader
 
 	lock sync.Mutex
+
+	// Whether to skip the lock in thread-safe methods.
+	noLock bool
+
+	// The custom epoch in Unix milliseconds subtracted from the wall clock.
+	epoch uint64
+
+	// The node id embedded in the top `nodeBits` bits of the entropy field.
+	nodeId   uint32
+	nodeBits uint8
+
+	// The metrics hooks notified of generation events, or nil.
+	metrics Metrics
+
+	// Whether to advance the timestamp by at least one for every ID.
+	strictTimestamp bool
+
+	// The random values pinned by generateWithEntropy, or nil.
+	pinned *pinnedRandom
+
+	// Whether to check the random number generator at construction.
+	entropyHealthCheck bool
+
+	// The random bytes read in advance by PrefetchEntropy and not consumed yet.
+	prefetched []byte
 }
 
 // Creates a generator object with the default random number generator.
@@ -61,23 +92,290 @@ type Generator struct {
 // bufio.NewReader(rand.Reader) to [NewGeneratorWithRng]:
 //
 //	go test -bench Generator
-func NewGenerator() *Generator {
+//
+// The behavior of the generator can be customized by [GeneratorOption] values.
+func NewGenerator(opts ...GeneratorOption) *Generator {
 	// use small buffer by default to avoid both occasional unbearable performance
 	// degradation and waste of time and space for unused buffer contents
 	br := bufio.NewReaderSize(rand.Reader, 32)
-	return NewGeneratorWithRng(br)
+	return NewGeneratorWithRng(br, opts...)
 }
 
 // Creates a generator object with a specified random number generator. The
 // specified random number generator should be cryptographically strong and
 // securely seeded.
 //
+// The behavior of the generator can be customized by [GeneratorOption] values.
+//
+// This constructor panics if `rng` is nil or if `rng` fails the health check
+// enabled by [WithEntropyHealthCheck]. Use [NewGeneratorChecked] to handle the
+// latter as an error.
+func NewGeneratorWithRng(rng io.Reader, opts ...GeneratorOption) *Generator {
+	g, err := NewGeneratorChecked(rng, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// Creates a generator object with a specified random number generator like
+// [NewGeneratorWithRng], but returns a non-nil err instead of panicking if the
+// health check enabled by [WithEntropyHealthCheck] fails, i.e., if `rng`
+// fails or returns the [ErrDegenerateEntropy] err.
+//
 // This constructor panics if `rng` is nil.
-func NewGeneratorWithRng(rng io.Reader) *Generator {
+func NewGeneratorChecked(rng io.Reader, opts ...GeneratorOption) (*Generator, error) {
 	if rng == nil {
 		panic("constructor called with nil `rng`")
 	}
-	return &Generator{rng: rng}
+	g := &Generator{
+		counterHiRenewalInterval: defaultCounterHiRenewalInterval,
+		rng:                      rng,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	if g.entropyHealthCheck {
+		if err := g.checkEntropy(); err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// The number of 32-bit random values that [WithEntropyHealthCheck] samples.
+const entropyHealthCheckSamples = 8
+
+// Reads several random values and returns the [ErrDegenerateEntropy] err if all
+// the bytes read are equal.
+func (g *Generator) checkEntropy() error {
+	first, err := g.randomUint32()
+	if err != nil {
+		return err
+	}
+	degenerate := first == first&0xff*0x0101_0101
+	for i := 1; i < entropyHealthCheckSamples; i++ {
+		n, err := g.randomUint32()
+		if err != nil {
+			return err
+		}
+		degenerate = degenerate && n == first
+	}
+	if degenerate {
+		return ErrDegenerateEntropy
+	}
+	return nil
+}
+
+// Creates a new generator object that starts from the same internal state as
+// the receiver.
+//
+// The clone shares the random number generator with the original, so the two
+// generators must not be used concurrently unless the random number generator
+// is safe for concurrent use. Note that the default random number generator
+// created by [NewGenerator] is not.
+func (g *Generator) Clone() *Generator {
+	if g == nil || g.rng == nil {
+		panic("method call on invalid receiver")
+	}
+	g.acquire()
+	defer g.release()
+	return &Generator{
+		timestamp:                g.timestamp,
+		counterHi:                g.counterHi,
+		counterLo:                g.counterLo,
+		tsCounterHi:              g.tsCounterHi,
+		counterHiRenewalInterval: g.counterHiRenewalInterval,
+		rng:                      g.rng,
+		noLock:                   g.noLock,
+		epoch:                    g.epoch,
+		nodeId:                   g.nodeId,
+		nodeBits:                 g.nodeBits,
+		metrics:                  g.metrics,
+		strictTimestamp:          g.strictTimestamp,
+		entropyHealthCheck:       g.entropyHealthCheck,
+	}
+}
+
+// Replaces the random number generator of the generator with `rng`, e.g., to
+// rotate to a faster buffered reader or a test reader, while keeping the
+// monotonic state.
+//
+// This method acquires the lock of the generator, but it should be called when
+// no generation is in flight because the calls that started before the swap
+// may still use the previous random number generator. The random bytes buffered
+// by [Generator.PrefetchEntropy] are discarded so that the subsequent
+// generation draws from the new random number generator right away.
+//
+// This method panics if `rng` is nil.
+func (g *Generator) SetRng(rng io.Reader) {
+	if g == nil || g.rng == nil {
+		panic("method call on invalid receiver")
+	} else if rng == nil {
+		panic("method called with nil `rng`")
+	}
+	g.acquire()
+	defer g.release()
+	g.rng = rng
+	for i := range g.prefetched {
+		g.prefetched[i] = 0
+	}
+	g.prefetched = nil
+}
+
+// Performs the initial seeding of the counter_hi field in advance so that the
+// first call of a generator method does not incur the extra random number
+// generator read.
+//
+// This method is a no-op if the generator has already seeded counter_hi, e.g.,
+// by generating an ID. It returns a non-nil err if the random number generator
+// fails.
+func (g *Generator) Warmup() error {
+	if g == nil || g.rng == nil {
+		panic("method call on invalid receiver")
+	}
+	g.acquire()
+	defer g.release()
+	if g.tsCounterHi != 0 {
+		return nil
+	}
+	n, err := g.randomUint32()
+	if err != nil {
+		return err
+	}
+	g.counterHi = n & maxCounterHi
+	g.tsCounterHi = g.now()
+	return nil
+}
+
+// The maximum number of IDs that [Generator.PrefetchEntropy] prepares for.
+const maxPrefetch = 1 << 20
+
+// Reads random bytes for roughly `n` future IDs in advance and buffers them
+// internally, so that the subsequent generation draws from the buffer instead
+// of the random number generator until the buffer is depleted.
+//
+// This method helps smooth the latency spikes of a slow random number
+// generator by moving the reads to a convenient moment, e.g., an idle period.
+// The buffer holds two 32-bit random values per ID, enough to fill the entropy
+// field and reseed counter_lo at a new millisecond, which the generator
+// consumes in the usual order, so the generator may use up the buffer earlier
+// or later than `n` IDs depending on the pattern of timestamps. Calling this
+// method again appends to the remaining buffer.
+//
+// Note that the buffer occupies eight bytes of memory per ID and that the
+// buffered random bytes, which determine the random fields of future IDs,
+// linger in memory until consumed, where they might be exposed through memory
+// dumps and similar means. The clone created by [Generator.Clone] does not
+// inherit the buffer, and [Generator.SetRng] discards it.
+//
+// This method returns a non-nil err if the random number generator fails, in
+// which case the buffer is left unchanged. It panics if `n` is negative or
+// greater than 1,048,576 (2^20).
+func (g *Generator) PrefetchEntropy(n int) error {
+	if g == nil || g.rng == nil {
+		panic("method call on invalid receiver")
+	} else if n < 0 || n > maxPrefetch {
+		panic("`n` out of reasonable range")
+	}
+	g.acquire()
+	defer g.release()
+	buffer := make([]byte, len(g.prefetched)+8*n)
+	copy(buffer, g.prefetched)
+	if _, err := io.ReadFull(g.rng, buffer[len(g.prefetched):]); err != nil {
+		return fmt.Errorf("scru128.Generator: random number generator error: %w", err)
+	}
+	g.prefetched = buffer
+	return nil
+}
+
+// Acquires the lock unless disabled by [WithoutLocking].
+func (g *Generator) acquire() {
+	if !g.noLock {
+		g.lock.Lock()
+	}
+}
+
+// Releases the lock unless disabled by [WithoutLocking].
+func (g *Generator) release() {
+	if !g.noLock {
+		g.lock.Unlock()
+	}
+}
+
+// Generates a new SCRU128 ID object like [Generator.Generate], or returns the
+// [ErrDeadlineExceeded] err if acquiring the lock or reading the random number
+// generator does not complete before `d`.
+//
+// This method is useful in latency-bounded request paths where heavy
+// contention or a slow custom random number generator could blow a deadline.
+// While another call holds the lock, this method polls the lock with an
+// exponential backoff (up to a millisecond between attempts) until it
+// succeeds or `d` passes. Once the lock is acquired, this method generates an
+// ID in the calling goroutine. If the random number generator has a
+// SetReadDeadline method, as os.File and net.Conn do, this method sets `d` as
+// its read deadline during the generation and returns the
+// [ErrDeadlineExceeded] err if a read times out. Otherwise, a read in progress
+// cannot be interrupted, and this method returns the ID generated even if `d`
+// passes in the meantime.
+//
+// This method returns a non-nil err if the random number generator fails.
+//
+// This method panics if the generator is configured by [WithoutLocking],
+// because it relies on the lock to bound the wait.
+func (g *Generator) GenerateWithDeadline(d time.Time) (id Id, err error) {
+	if g == nil || g.rng == nil {
+		panic("method call on invalid receiver")
+	} else if g.noLock {
+		panic("method call on generator without locking")
+	}
+
+	backoff := minDeadlineBackoff
+	for !g.lock.TryLock() {
+		wait := time.Until(d)
+		if wait <= 0 {
+			return Id{}, ErrDeadlineExceeded
+		} else if wait > backoff {
+			wait = backoff
+		}
+		time.Sleep(wait)
+		if backoff < maxDeadlineBackoff {
+			backoff *= 2
+		}
+	}
+	defer g.lock.Unlock()
+	if !time.Now().Before(d) {
+		return Id{}, ErrDeadlineExceeded
+	}
+
+	if r, ok := g.rng.(interface{ SetReadDeadline(time.Time) error }); ok &&
+		r.SetReadDeadline(d) == nil {
+		defer r.SetReadDeadline(time.Time{})
+	}
+	id, err = g.GenerateOrResetCore(g.now(), defaultRollbackAllowance)
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		return Id{}, ErrDeadlineExceeded
+	}
+	return
+}
+
+// The initial interval at which [Generator.GenerateWithDeadline] polls the
+// lock.
+const minDeadlineBackoff = 10 * time.Microsecond
+
+// The maximum interval at which [Generator.GenerateWithDeadline] polls the
+// lock.
+const maxDeadlineBackoff = time.Millisecond
+
+// Returns the current `timestamp` measured from the epoch of the generator, or
+// one if the wall clock is at or before the epoch.
+func (g *Generator) now() uint64 {
+	ms := time.Now().UnixMilli()
+	if ms <= int64(g.epoch) {
+		// clamp to smallest valid timestamp instead of wrapping around
+		return 1
+	}
+	return uint64(ms) - g.epoch
 }
 
 // Generates a new SCRU128 ID object from the current `timestamp`, or resets the
@@ -87,10 +385,10 @@ func NewGeneratorWithRng(rng io.Reader) *Generator {
 //
 // This method returns a non-nil err if the random number generator fails.
 func (g *Generator) Generate() (id Id, err error) {
-	g.lock.Lock()
-	defer g.lock.Unlock()
+	g.acquire()
+	defer g.release()
 	return g.GenerateOrResetCore(
-		uint64(time.Now().UnixMilli()),
+		g.now(),
 		defaultRollbackAllowance,
 	)
 }
@@ -103,14 +401,278 @@ func (g *Generator) Generate() (id Id, err error) {
 // This method returns a non-nil err if the random number generator fails or
 // returns the [ErrClockRollback] err upon significant clock rollback.
 func (g *Generator) GenerateOrAbort() (id Id, err error) {
-	g.lock.Lock()
-	defer g.lock.Unlock()
+	g.acquire()
+	defer g.release()
 	return g.GenerateOrAbortCore(
-		uint64(time.Now().UnixMilli()),
+		g.now(),
+		defaultRollbackAllowance,
+	)
+}
+
+// Generates a new SCRU128 ID encoded in the 25-digit canonical string
+// representation, or resets the generator upon significant timestamp rollback.
+//
+// This method is equivalent to [Generator.Generate] followed by [Id.String]
+// and returns a non-nil err if the random number generator fails.
+func (g *Generator) GenerateString() (string, error) {
+	id, err := g.Generate()
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// Generates a new SCRU128 ID encoded in the 25-digit canonical string
+// representation, or returns an error upon significant timestamp rollback.
+//
+// This method is equivalent to [Generator.GenerateOrAbort] followed by
+// [Id.String] and returns a non-nil err if the random number generator fails or
+// returns the [ErrClockRollback] err upon significant clock rollback.
+func (g *Generator) GenerateStringOrAbort() (string, error) {
+	id, err := g.GenerateOrAbort()
+	if err != nil {
+		return "", err
+	}
+	return id.String(), nil
+}
+
+// Generates a new SCRU128 ID as the 16-byte big-endian byte array, or resets
+// the generator upon significant timestamp rollback.
+//
+// This method is equivalent to [Generator.Generate] followed by a conversion to
+// [16]byte, which is useful for binary-first callers that serialize IDs
+// immediately. It returns a non-nil err if the random number generator fails.
+func (g *Generator) GenerateBytes() ([16]byte, error) {
+	id, err := g.Generate()
+	return [16]byte(id), err
+}
+
+// Generates a new SCRU128 ID object from the current `timestamp`, or resets the
+// generator upon significant timestamp rollback, reporting whether the reset
+// occurred.
+//
+// This method behaves the same as [Generator.Generate] except that `reset` is
+// true if the returned ID breaks the increasing order of IDs because the
+// generator was reset upon significant clock rollback.
+//
+// This method returns a non-nil err if the random number generator fails.
+func (g *Generator) GenerateReportReset() (id Id, reset bool, err error) {
+	g.acquire()
+	defer g.release()
+	return g.generateOrResetCore(
+		g.now(),
 		defaultRollbackAllowance,
 	)
 }
 
+// Generates a new SCRU128 ID object using a timestamp strictly greater than the
+// immediately preceding ID's, sleeping until the wall clock advances if
+// necessary.
+//
+// This method behaves the same as [Generator.Generate] except that, if the
+// current `timestamp` is not greater than that of the immediately preceding
+// ID, it sleeps until the wall clock reaches the next millisecond. This is
+// useful when external systems key solely on the timestamp field, but it adds
+// up to about a millisecond of latency per call (longer if the clock went
+// backwards slightly) and blocks other callers of the generator in the
+// meantime. When the clock went backwards significantly, this method resets
+// the generator without sleeping.
+//
+// This method returns a non-nil err if the random number generator fails.
+func (g *Generator) GenerateNewMillis() (id Id, err error) {
+	g.acquire()
+	defer g.release()
+	timestamp := g.now()
+	if timestamp <= g.timestamp &&
+		timestamp+defaultRollbackAllowance >= g.timestamp {
+		time.Sleep(time.Until(time.UnixMilli(int64(g.epoch + g.timestamp + 1))))
+		timestamp = g.now()
+	}
+	return g.GenerateOrResetCore(timestamp, defaultRollbackAllowance)
+}
+
+// Generates a new SCRU128 ID object from the `timestamp` passed, or returns an
+// error upon significant timestamp rollback.
+//
+// This method is a thread-safe counterpart of [Generator.GenerateOrAbortCore]
+// with the default rollback allowance (ten seconds), useful to assign IDs that
+// follow an existing timestamp key (e.g., a `created_at` column) in ascending
+// order. Like the other methods, the generator reuses the previous timestamp
+// if `unixMilli` is slightly smaller than the immediately preceding ID's.
+//
+// This method returns a non-nil err if the random number generator fails or
+// returns the [ErrClockRollback] err upon significant timestamp rollback.
+//
+// If the generator is configured by [WithEpoch], the epoch is subtracted from
+// `unixMilli` before embedding it.
+//
+// This method panics if `unixMilli` is not a 48-bit positive integer (after
+// the epoch is subtracted).
+func (g *Generator) GenerateForTimestamp(unixMilli uint64) (id Id, err error) {
+	g.acquire()
+	defer g.release()
+	return g.GenerateOrAbortCore(unixMilli-g.epoch, defaultRollbackAllowance)
+}
+
+// Generates a new SCRU128 ID object from the millisecond part of `unixNano`,
+// or returns an error upon significant timestamp rollback.
+//
+// This method is equivalent to [Generator.GenerateForTimestamp] with
+// `unixNano` divided by 1,000,000, for callers that track event times in
+// nanoseconds. SCRU128 has no field for the sub-millisecond part, which this
+// method discards; the IDs that share the millisecond are ordered only by the
+// counters, so the sub-millisecond order of events is preserved only if the
+// calls arrive in the order of `unixNano`.
+//
+// This method returns a non-nil err if the random number generator fails or
+// returns the [ErrClockRollback] err upon significant timestamp rollback.
+//
+// This method panics if `unixNano` is negative or if its millisecond part is
+// not a 48-bit positive integer (after the epoch is subtracted).
+func (g *Generator) GenerateForUnixNano(unixNano int64) (id Id, err error) {
+	if unixNano < 0 {
+		panic("`unixNano` must not be negative")
+	}
+	return g.GenerateForTimestamp(uint64(unixNano / 1_000_000))
+}
+
+// Generates a new SCRU128 ID object from the current `timestamp` that sorts
+// after `prev`, with the (timestamp, counter_hi, counter_lo) tuple strictly
+// greater than that of `prev`.
+//
+// If the generator state is behind `prev`, this method first fast-forwards the
+// generator to the state that would have produced `prev`, so the generator
+// continues to produce IDs greater than `prev` afterwards. Unlike the other
+// fields, the entropy field of the result is freshly generated and has no
+// relation to that of `prev`.
+//
+// This method returns a non-nil err if the random number generator fails or
+// returns the [ErrClockRollback] err if `prev` or the immediately preceding ID
+// is significantly ahead of the current `timestamp`.
+func (g *Generator) GenerateAfter(prev Id) (id Id, err error) {
+	if g == nil || g.rng == nil {
+		panic("method call on invalid receiver")
+	}
+	g.acquire()
+	defer g.release()
+	timestamp := g.now()
+	if prev.Timestamp() > timestamp+defaultRollbackAllowance {
+		return Id{}, ErrClockRollback
+	}
+
+	g.fastForward(prev)
+	return g.GenerateOrAbortCore(timestamp, defaultRollbackAllowance)
+}
+
+// Fast-forwards the generator to the state that would have produced `prev` if
+// the generator state is behind `prev`.
+func (g *Generator) fastForward(prev Id) {
+	if FromFields(g.timestamp, g.counterHi, g.counterLo, 0).Cmp(
+		FromFields(prev.Timestamp(), prev.CounterHi(), prev.CounterLo(), 0)) < 0 {
+		g.timestamp = prev.Timestamp()
+		g.counterHi = prev.CounterHi()
+		g.counterLo = prev.CounterLo()
+		g.tsCounterHi = prev.Timestamp()
+	}
+}
+
+// Generates a new SCRU128 ID object using [Generator.Generate], regenerating
+// while `seen` reports that the ID already exists.
+//
+// Though collisions of SCRU128 IDs are astronomically unlikely, this method
+// offers a defensive check against external uniqueness constraints. The
+// generator lock is not held while `seen` is called.
+//
+// This method returns a non-nil err if the random number generator fails or
+// returns the [ErrTooManyDuplicates] err if `seen` reports duplicates
+// repeatedly.
+func (g *Generator) GenerateUnique(seen func(Id) bool) (id Id, err error) {
+	for i := 0; i < maxUniqueAttempts; i++ {
+		id, err = g.Generate()
+		if err != nil || !seen(id) {
+			return
+		}
+	}
+	return Id{}, ErrTooManyDuplicates
+}
+
+// The maximum number of attempts [Generator.GenerateUnique] makes.
+const maxUniqueAttempts = 8
+
+// The error value returned by [Generator.GenerateUnique] when the IDs
+// generated are reported as duplicates repeatedly.
+var ErrTooManyDuplicates = fmt.Errorf(
+	"scru128.Generator: could not generate ID not seen before")
+
+// The maximum number of IDs that [Generator.ReserveBlock] reserves at a time.
+const maxBlockSize = maxCounterLo + 1
+
+// Reserves `n` consecutive values of the combined 48-bit counter (counter_hi
+// and counter_lo) for the current `timestamp` and returns the first ID of the
+// block, or resets the generator upon significant timestamp rollback.
+//
+// This method lets batch inserts that need consecutive IDs acquire the lock
+// only once. The caller derives the i-th ID (0 <= i < n) of the block by
+// adding `i` to the counter, i.e., `uint64(i) << 32` to the 128-bit integer,
+// keeping the timestamp and entropy fields of the first ID:
+//
+//	FromUint128(first.ToUint128().Add(Uint128{Lo: uint64(i) << 32}))
+//
+// The derived IDs never carry over into the timestamp field, and the IDs the
+// generator produces afterwards are greater than all of them. If the counter
+// space remaining in the current timestamp is not large enough for `n` IDs,
+// the generator increments the timestamp as it does upon counter overflow and
+// reserves the block there, starting counter_hi from zero. Note that the derived IDs share the entropy field
+// and hence are more predictable than the IDs generated one by one, and that
+// they share the timestamp even if the generator is configured by
+// [WithStrictTimestampMonotonicity].
+//
+// This method returns a non-nil err if the random number generator fails or
+// returns the [ErrTimestampOverflow] err if the counters are exhausted at the
+// maximum timestamp.
+//
+// This method panics if `n` is zero or greater than 16,777,216 (2^24).
+func (g *Generator) ReserveBlock(n uint32) (first Id, err error) {
+	if n == 0 || n > maxBlockSize {
+		panic("`n` out of range")
+	}
+	g.acquire()
+	defer g.release()
+	first, err = g.GenerateOrResetCore(g.now(), defaultRollbackAllowance)
+	if err != nil {
+		return Id{}, err
+	}
+	counter := uint64(g.counterHi)<<24 | uint64(g.counterLo)
+	if maxCounter-counter < uint64(n-1) {
+		// move on to next timestamp as upon counter overflow, starting counter_hi
+		// from zero so that the block always fits
+		if g.timestamp == maxTimestamp {
+			g.counterHi, g.counterLo = maxCounterHi, maxCounterLo
+			return Id{}, ErrTimestampOverflow
+		}
+		g.timestamp++
+		if g.metrics != nil {
+			g.metrics.IncCounterOverflow()
+		}
+		var lo uint32
+		lo, err = g.randomCounterLo()
+		if err != nil {
+			return Id{}, err
+		}
+		g.counterHi = 0
+		g.counterLo = lo & maxCounterLo
+		if g.timestamp-g.tsCounterHi >= g.counterHiRenewalInterval {
+			g.tsCounterHi = g.timestamp
+		}
+		first = FromFields(g.timestamp, g.counterHi, g.counterLo, first.Entropy())
+		counter = uint64(g.counterLo)
+	}
+	counter += uint64(n - 1)
+	g.counterHi = uint32(counter >> 24)
+	g.counterLo = uint32(counter) & maxCounterLo
+	return first, nil
+}
+
 // Generates a new SCRU128 ID object from the `timestamp` passed, or resets the
 // generator upon significant timestamp rollback.
 //
@@ -123,18 +685,32 @@ func (g *Generator) GenerateOrAbort() (id Id, err error) {
 // object should be protected from concurrent accesses using a mutex or other
 // synchronization mechanism to avoid race conditions.
 //
-// This method returns a non-nil err if the random number generator fails.
+// This method returns a non-nil err if the random number generator fails. If
+// the generator is configured by [WithStrictTimestampMonotonicity], this method
+// does not reset the generator and returns the [ErrClockRollback] err upon
+// significant clock rollback instead.
 //
 // This method panics if `timestamp` is not a 48-bit positive integer.
 func (g *Generator) GenerateOrResetCore(
 	timestamp uint64,
 	rollbackAllowance uint64,
 ) (id Id, err error) {
+	id, _, err = g.generateOrResetCore(timestamp, rollbackAllowance)
+	return
+}
+
+// Implements [Generator.GenerateOrResetCore], additionally reporting whether
+// the generator was reset.
+func (g *Generator) generateOrResetCore(
+	timestamp uint64,
+	rollbackAllowance uint64,
+) (id Id, reset bool, err error) {
 	id, err = g.GenerateOrAbortCore(timestamp, rollbackAllowance)
-	if err == ErrClockRollback {
+	if err == ErrClockRollback && !g.strictTimestamp {
 		// reset state and resume
 		g.timestamp = 0
 		g.tsCounterHi = 0
+		reset = true
 		id, err = g.GenerateOrAbortCore(timestamp, rollbackAllowance)
 	}
 	return
@@ -153,7 +729,9 @@ func (g *Generator) GenerateOrResetCore(
 // or other synchronization mechanism to avoid race conditions.
 //
 // This method returns a non-nil err if the random number generator fails or
-// returns the [ErrClockRollback] err upon significant clock rollback.
+// returns the [ErrClockRollback] err upon significant clock rollback. It also
+// returns the [ErrTimestampOverflow] err if the counters are exhausted at the
+// maximum timestamp.
 //
 // This method panics if `timestamp` is not a 48-bit positive integer.
 func (g *Generator) GenerateOrAbortCore(
@@ -168,10 +746,19 @@ func (g *Generator) GenerateOrAbortCore(
 		panic("`rollbackAllowance` out of reasonable range")
 	}
 
+	if g.strictTimestamp && timestamp <= g.timestamp &&
+		timestamp+rollbackAllowance >= g.timestamp {
+		// advance logical timestamp instead of incrementing counters
+		if g.timestamp == maxTimestamp {
+			return Id{}, ErrTimestampOverflow
+		}
+		timestamp = g.timestamp + 1
+	}
+
 	var n uint32
 	if timestamp > g.timestamp {
 		g.timestamp = timestamp
-		n, err = g.randomUint32()
+		n, err = g.randomCounterLo()
 		if err != nil {
 			return Id{}, err
 		}
@@ -183,10 +770,18 @@ func (g *Generator) GenerateOrAbortCore(
 			g.counterLo = 0
 			g.counterHi++
 			if g.counterHi > maxCounterHi {
+				if g.timestamp == maxTimestamp {
+					// keep counters exhausted so that subsequent calls fail as well
+					g.counterHi, g.counterLo = maxCounterHi, maxCounterLo
+					return Id{}, ErrTimestampOverflow
+				}
 				g.counterHi = 0
 				// increment timestamp at counter overflow
 				g.timestamp++
-				n, err = g.randomUint32()
+				if g.metrics != nil {
+					g.metrics.IncCounterOverflow()
+				}
+				n, err = g.randomCounterLo()
 				if err != nil {
 					return Id{}, err
 				}
@@ -195,28 +790,157 @@ func (g *Generator) GenerateOrAbortCore(
 		}
 	} else {
 		// abort if clock went backwards to unbearable extent
+		if g.metrics != nil {
+			g.metrics.IncRollback()
+		}
 		return Id{}, ErrClockRollback
 	}
 
-	if g.timestamp-g.tsCounterHi >= 1_000 || g.tsCounterHi == 0 {
+	if g.timestamp-g.tsCounterHi >= g.counterHiRenewalInterval ||
+		g.tsCounterHi == 0 {
 		g.tsCounterHi = g.timestamp
-		n, err = g.randomUint32()
+		n, err = g.randomCounterHi()
 		if err != nil {
 			return Id{}, err
 		}
 		g.counterHi = n & maxCounterHi
 	}
 
-	n, err = g.randomUint32()
+	n, err = g.randomEntropy()
 	if err != nil {
 		return Id{}, err
 	}
+	if g.nodeBits > 0 {
+		// replace top bits of entropy with node id
+		n = g.nodeId<<(32-g.nodeBits) | n>>g.nodeBits
+	}
+	if g.metrics != nil {
+		g.metrics.IncGenerated()
+	}
 	return FromFields(g.timestamp, g.counterHi, g.counterLo, n), nil
 }
 
+// Generates a new SCRU128 ID object from the `timestamp` passed, or returns an
+// error upon significant timestamp rollback or invalid `timestamp`.
+//
+// This method is equivalent to [Generator.GenerateOrAbortCore] except that it
+// returns the [ErrInvalidTimestamp] err instead of panicking if `timestamp` is
+// not a 48-bit positive integer, which helps library code that forwards
+// external timestamps. Like [Generator.GenerateOrAbortCore], this method is
+// NOT thread-safe.
+//
+// This method still panics if `rollbackAllowance` is out of reasonable range.
+func (g *Generator) GenerateOrAbortCoreChecked(
+	timestamp uint64,
+	rollbackAllowance uint64,
+) (id Id, err error) {
+	if timestamp == 0 || timestamp > maxTimestamp {
+		return Id{}, ErrInvalidTimestamp
+	}
+	return g.GenerateOrAbortCore(timestamp, rollbackAllowance)
+}
+
+// Represents a snapshot of the internal state of a [Generator], which is
+// useful to monitor how close the generator is to counter exhaustion.
+type GeneratorStats struct {
+	// The timestamp embedded in the immediately preceding ID.
+	Timestamp uint64
+
+	// The counter_hi field value of the immediately preceding ID.
+	CounterHi uint32
+
+	// The counter_lo field value of the immediately preceding ID.
+	CounterLo uint32
+
+	// The fraction in the range of [0, 1] of the combined 48-bit counter space
+	// (counter_hi and counter_lo) consumed within the current timestamp. The
+	// generator increments the timestamp when the counter overflows (i.e.,
+	// when the utilization reaches one).
+	CounterUtilization float64
+}
+
+// Returns a snapshot of the internal state of the generator.
+//
+// This method is thread-safe; it acquires the same lock as
+// [Generator.Generate].
+func (g *Generator) Stats() GeneratorStats {
+	if g == nil || g.rng == nil {
+		panic("method call on invalid receiver")
+	}
+	g.acquire()
+	defer g.release()
+	counter := uint64(g.counterHi)<<24 | uint64(g.counterLo)
+	return GeneratorStats{
+		Timestamp:          g.timestamp,
+		CounterHi:          g.counterHi,
+		CounterLo:          g.counterLo,
+		CounterUtilization: float64(counter) / float64(maxCounter),
+	}
+}
+
+// Returns the number of IDs that can be generated within the current
+// timestamp before the counter overflows and forces the generator to increment
+// the timestamp.
+//
+// The returned value is computed from the state after the immediately
+// preceding ID and is only meaningful while the generator is fed the same
+// timestamp; a new timestamp renews the counter_lo field (and periodically the
+// counter_hi field) with random numbers.
+//
+// This method is thread-safe; it acquires the same lock as
+// [Generator.Generate].
+func (g *Generator) RemainingThisMillis() uint64 {
+	if g == nil || g.rng == nil {
+		panic("method call on invalid receiver")
+	}
+	g.acquire()
+	defer g.release()
+	return maxCounter - (uint64(g.counterHi)<<24 | uint64(g.counterLo))
+}
+
+// Reports whether generating `ratePerMillis` IDs every millisecond from the
+// current state of the generator is expected to exhaust the counter before the
+// generator renews counter_hi, forcing the generator to increment the timestamp
+// ahead of the wall clock.
+//
+// The counter_lo field is seeded with a random number at every new millisecond
+// and carries over into counter_hi, which persists across milliseconds until
+// renewed at the interval configured by [WithCounterHiRenewalInterval] (one
+// second by default). Hence, at `ratePerMillis` IDs per millisecond,
+// counter_hi increases by `(ratePerMillis - 1) / 2^24` per millisecond on
+// average, and this method returns true if that increase over the remaining
+// milliseconds until the renewal exceeds the room left in counter_hi. This
+// method also returns true if `ratePerMillis` is greater than
+// [MaxRatePerMillis]. Before the generator seeds counter_hi, e.g., by
+// [Generator.Warmup], this method evaluates counter_hi as zero.
+//
+// This method is thread-safe; it acquires the same lock as
+// [Generator.Generate].
+func (g *Generator) WouldExhaustAt(ratePerMillis uint64) bool {
+	if g == nil || g.rng == nil {
+		panic("method call on invalid receiver")
+	}
+	if ratePerMillis == 0 {
+		return false
+	} else if ratePerMillis > MaxRatePerMillis() {
+		return true
+	}
+	g.acquire()
+	defer g.release()
+	left := g.counterHiRenewalInterval
+	if elapsed := g.timestamp - g.tsCounterHi; g.tsCounterHi != 0 && elapsed < left {
+		left -= elapsed
+	}
+	hi, lo := bits.Mul64(left, ratePerMillis-1)
+	return hi > 0 || lo > uint64(maxCounterHi-g.counterHi)<<24
+}
+
 // The default timestamp rollback allowance.
 const defaultRollbackAllowance = 10_000 // 10 seconds
 
+// The default interval at which counter_hi field is renewed.
+const defaultCounterHiRenewalInterval = 1_000 // 1 second
+
 // The error value returned by [Generator.GenerateOrAbort] and
 // [Generator.GenerateOrAbortCore] when the relevant timestamp is significantly
 // smaller than the one embedded in the immediately preceding ID generated by
@@ -224,11 +948,88 @@ const defaultRollbackAllowance = 10_000 // 10 seconds
 var ErrClockRollback = fmt.Errorf(
 	"scru128.Generator: detected unbearable clock rollback")
 
-// Returns a random uint32 value.
+// The error value returned by [Generator.GenerateOrAbortCore] and the methods
+// built on it when the counters overflow at the maximum 48-bit timestamp and
+// the generator has no room to increment the timestamp.
+var ErrTimestampOverflow = fmt.Errorf(
+	"scru128.Generator: timestamp overflow at counter exhaustion")
+
+// The error value returned by [Generator.GenerateWithDeadline] when the
+// deadline passes before an ID is generated.
+var ErrDeadlineExceeded = fmt.Errorf("scru128.Generator: deadline exceeded")
+
+// The error value returned by [Generator.GenerateOrAbortCoreChecked] when the
+// `timestamp` passed is not a 48-bit positive integer.
+var ErrInvalidTimestamp = fmt.Errorf(
+	"scru128.Generator: `timestamp` must be a 48-bit positive integer")
+
+// The error value returned by [NewGeneratorChecked] when the random number
+// generator appears degenerate in the health check enabled by
+// [WithEntropyHealthCheck].
+var ErrDegenerateEntropy = fmt.Errorf(
+	"scru128.Generator: random number generator returned degenerate bytes")
+
+// Holds the random values that generateWithEntropy pins.
+type pinnedRandom struct {
+	counterHi, counterLo, entropy uint32
+}
+
+// Generates a new SCRU128 ID object like [Generator.GenerateOrAbortCore] but
+// uses the specified values instead of reading the random number generator
+// whenever the generator renews counter_hi (`counterHiSeed`), reseeds
+// counter_lo (`counterLoSeed`), and fills the entropy field (`entropy`). The
+// counter seeds are masked to 24 bits as the random numbers are.
+//
+// This is an advanced, test-oriented primitive that pins every random value to
+// make the three-layer randomness deterministic, e.g., to test the renewal of
+// counter_hi without relying on the random number generator.
+func (g *Generator) generateWithEntropy(
+	timestamp uint64,
+	rollbackAllowance uint64,
+	counterHiSeed, counterLoSeed, entropy uint32,
+) (Id, error) {
+	g.pinned = &pinnedRandom{counterHiSeed, counterLoSeed, entropy}
+	defer func() { g.pinned = nil }()
+	return g.GenerateOrAbortCore(timestamp, rollbackAllowance)
+}
+
+// Returns a random uint32 value to renew counter_hi.
+func (g *Generator) randomCounterHi() (uint32, error) {
+	if g.pinned != nil {
+		return g.pinned.counterHi, nil
+	}
+	return g.randomUint32()
+}
+
+// Returns a random uint32 value to reseed counter_lo.
+func (g *Generator) randomCounterLo() (uint32, error) {
+	if g.pinned != nil {
+		return g.pinned.counterLo, nil
+	}
+	return g.randomUint32()
+}
+
+// Returns a random uint32 value to fill the entropy field.
+func (g *Generator) randomEntropy() (uint32, error) {
+	if g.pinned != nil {
+		return g.pinned.entropy, nil
+	}
+	return g.randomUint32()
+}
+
+// Returns a random uint32 value, drawing from the buffer prepared by
+// PrefetchEntropy if available.
 func (g *Generator) randomUint32() (uint32, error) {
 	b := make([]byte, 4)
-	_, err := g.rng.Read(b)
-	if err != nil {
+	var err error
+	if len(g.prefetched) >= len(b) {
+		copy(b, g.prefetched)
+		copy(g.prefetched, []byte{0, 0, 0, 0}) // wipe consumed bytes
+		g.prefetched = g.prefetched[len(b):]
+		if len(g.prefetched) == 0 {
+			g.prefetched = nil
+		}
+	} else if _, err = g.rng.Read(b); err != nil {
 		err = fmt.Errorf("scru128.Generator: random number generator error: %w", err)
 	}
 	_ = b[3] // bounds check hint to compiler
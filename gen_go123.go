@@ -0,0 +1,30 @@
+//go:build go1.23
+
+package scru128
+
+import "iter"
+
+// Returns an iterator that generates up to `n` new SCRU128 IDs using
+// [Generator.Generate].
+//
+// The generator lock is acquired and released for each ID and is not held
+// while the loop body runs, so the loop body may use the same generator. The
+// iterator stops after yielding the first non-nil err returned by the random
+// number generator.
+//
+//	for id, err := range g.Iter(1000) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (g *Generator) Iter(n int) iter.Seq2[Id, error] {
+	return func(yield func(Id, error) bool) {
+		for i := 0; i < n; i++ {
+			id, err := g.Generate()
+			if !yield(id, err) || err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,70 @@
+//go:build go1.23
+
+package scru128
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// Iterates over increasing IDs
+func TestIter(t *testing.T) {
+	g := NewGenerator()
+	var ids []Id
+	for id, err := range g.Iter(1_000) {
+		if err != nil {
+			t.Fail()
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) != 1_000 {
+		t.Fail()
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i-1].Cmp(ids[i]) >= 0 {
+			t.Fail()
+		}
+	}
+
+	for range g.Iter(0) {
+		t.Fail()
+	}
+}
+
+// Stops iteration upon break
+func TestIterBreak(t *testing.T) {
+	g := NewGenerator()
+	count := 0
+	for id := range g.Iter(1_000) {
+		count++
+		if count == 10 {
+			break
+		}
+		// loop body can use same generator
+		if next, _ := g.Generate(); next.Cmp(id) <= 0 {
+			t.Fail()
+		}
+	}
+	if count != 10 {
+		t.Fail()
+	}
+}
+
+// Yields error from random number generator and stops
+func TestIterError(t *testing.T) {
+	g := NewGeneratorWithRng(bytes.NewReader(make([]byte, 4*10)))
+	count, errCount := 0, 0
+	for _, err := range g.Iter(1_000) {
+		count++
+		if err != nil {
+			errCount++
+			if err.Error() != "scru128.Generator: random number generator error: "+io.EOF.Error() {
+				t.Fail()
+			}
+		}
+	}
+	if errCount != 1 || count >= 1_000 {
+		t.Fail()
+	}
+}
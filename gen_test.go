@@ -2,9 +2,15 @@ package scru128
 
 import (
 	"bufio"
+	"bytes"
 	crand "crypto/rand"
+	"errors"
+	"io"
 	mrand "math/rand"
+	"os"
+	"sync"
 	"testing"
+	"testing/iotest"
 	"time"
 )
 
@@ -131,6 +137,779 @@ func TestTimestampRollbackAbort(t *testing.T) {
 	}
 }
 
+// Swaps random number generator at runtime
+func TestSetRng(t *testing.T) {
+	g := NewGenerator()
+	prev, err := g.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g.SetRng(iotest.ErrReader(io.ErrNoProgress))
+	for i := 0; i < 2; i++ {
+		if _, err := g.Generate(); !errors.Is(err, io.ErrNoProgress) {
+			t.Fail()
+		}
+	}
+
+	g.SetRng(NewInsecureCountingGenerator().rng)
+	curr, err := g.Generate()
+	if err != nil || curr.Cmp(prev) <= 0 {
+		t.Fail()
+	}
+}
+
+// Returns error instead of panicking if counters overflow at max timestamp
+func TestTimestampOverflow(t *testing.T) {
+	g := NewGenerator()
+	g.timestamp = maxTimestamp
+	g.counterHi = maxCounterHi
+	g.counterLo = maxCounterLo - 1
+	g.tsCounterHi = maxTimestamp
+
+	x, err := g.GenerateOrAbortCore(maxTimestamp, 10_000)
+	if err != nil || x != FromFields(maxTimestamp, maxCounterHi, maxCounterLo, x.Entropy()) {
+		t.Fail()
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := g.GenerateOrAbortCore(maxTimestamp, 10_000); err != ErrTimestampOverflow {
+			t.Fail()
+		}
+		if _, err := g.GenerateOrResetCore(maxTimestamp, 10_000); err != ErrTimestampOverflow {
+			t.Fail()
+		}
+	}
+}
+
+// Renews counter_hi at 1000 ms boundary with pinned random values
+func TestGenerateWithEntropy(t *testing.T) {
+	var ts uint64 = 0x0123_4567_89ab
+	g := NewGenerator()
+
+	x, _ := g.generateWithEntropy(ts, 10_000, 0x111111, 0x222222, 0x33333333)
+	if x != FromFields(ts, 0x111111, 0x222222, 0x33333333) {
+		t.Fail()
+	}
+
+	// increments counter_lo within same timestamp
+	x, _ = g.generateWithEntropy(ts, 10_000, 0x444444, 0x555555, 0x66666666)
+	if x != FromFields(ts, 0x111111, 0x222223, 0x66666666) {
+		t.Fail()
+	}
+
+	// reseeds counter_lo but keeps counter_hi before 1000 ms elapse
+	x, _ = g.generateWithEntropy(ts+999, 10_000, 0x444444, 0x555555, 0x66666666)
+	if x != FromFields(ts+999, 0x111111, 0x555555, 0x66666666) {
+		t.Fail()
+	}
+
+	// renews counter_hi once 1000 ms elapse
+	x, _ = g.generateWithEntropy(ts+1_000, 10_000, 0x777777, 0x888888, 0x99999999)
+	if x != FromFields(ts+1_000, 0x777777, 0x888888, 0x99999999) {
+		t.Fail()
+	}
+	x, _ = g.generateWithEntropy(ts+1_999, 10_000, 0xaaaaaa, 0xbbbbbb, 0)
+	if x != FromFields(ts+1_999, 0x777777, 0xbbbbbb, 0) {
+		t.Fail()
+	}
+	x, _ = g.generateWithEntropy(ts+2_000, 10_000, 0xffcccccc, 0xffdddddd, 0)
+	if x != FromFields(ts+2_000, 0xcccccc, 0xdddddd, 0) {
+		t.Fail()
+	}
+
+	// reads random number generator again after pinned call
+	if g.pinned != nil {
+		t.Fail()
+	}
+}
+
+// Returns error instead of panicking upon invalid timestamp
+func TestGenerateOrAbortCoreChecked(t *testing.T) {
+	g := NewGenerator()
+	for _, e := range []uint64{0, maxTimestamp + 1, 1 << 63} {
+		if _, err := g.GenerateOrAbortCoreChecked(e, 10_000); err != ErrInvalidTimestamp {
+			t.Fail()
+		}
+	}
+	if g.timestamp != 0 {
+		t.Fail()
+	}
+
+	for _, e := range []uint64{1, 0x0123_4567_89ab, maxTimestamp} {
+		x, err := g.GenerateOrAbortCoreChecked(e, 10_000)
+		if err != nil || x.Timestamp() != e {
+			t.Fail()
+		}
+	}
+	if _, err := g.GenerateOrAbortCoreChecked(1, 10_000); err != ErrClockRollback {
+		t.Fail()
+	}
+}
+
+// A reader that sleeps before reading from the underlying reader
+type slowReader struct {
+	r     io.Reader
+	delay time.Duration
+}
+
+func (r slowReader) Read(p []byte) (int, error) {
+	time.Sleep(r.delay)
+	return r.r.Read(p)
+}
+
+// Implements SetReadDeadline and times out reads slower than deadline
+type deadlineReader struct {
+	r        io.Reader
+	delay    time.Duration
+	deadline *time.Time
+}
+
+func (r deadlineReader) Read(p []byte) (int, error) {
+	if !r.deadline.IsZero() && time.Until(*r.deadline) < r.delay {
+		time.Sleep(time.Until(*r.deadline))
+		return 0, os.ErrDeadlineExceeded
+	}
+	time.Sleep(r.delay)
+	return r.r.Read(p)
+}
+
+func (r deadlineReader) SetReadDeadline(t time.Time) error {
+	*r.deadline = t
+	return nil
+}
+
+// Returns error if deadline passes before generation completes
+func TestGenerateWithDeadline(t *testing.T) {
+	g := NewGenerator()
+	prev, err := g.GenerateWithDeadline(time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.GenerateWithDeadline(time.Now().Add(-time.Millisecond)); err != ErrDeadlineExceeded {
+		t.Fail()
+	}
+
+	// contended lock
+	g.lock.Lock()
+	start := time.Now()
+	if _, err := g.GenerateWithDeadline(start.Add(20 * time.Millisecond)); err != ErrDeadlineExceeded ||
+		time.Since(start) < 20*time.Millisecond || time.Since(start) > time.Second {
+		t.Fail()
+	}
+	g.lock.Unlock()
+
+	// acquires lock released before deadline
+	g.lock.Lock()
+	time.AfterFunc(10*time.Millisecond, g.lock.Unlock)
+	curr, err := g.GenerateWithDeadline(time.Now().Add(time.Second))
+	if err != nil || curr.Cmp(prev) <= 0 {
+		t.Fail()
+	}
+	prev = curr
+
+	// slow random number generator with read deadline
+	var deadline time.Time
+	g.SetRng(deadlineReader{crand.Reader, 200 * time.Millisecond, &deadline})
+	start = time.Now()
+	if _, err := g.GenerateWithDeadline(start.Add(20 * time.Millisecond)); err != ErrDeadlineExceeded ||
+		time.Since(start) > 150*time.Millisecond || !deadline.IsZero() {
+		t.Fail()
+	}
+
+	// slow random number generator without read deadline
+	g.SetRng(slowReader{crand.Reader, 50 * time.Millisecond})
+	curr, err = g.GenerateWithDeadline(time.Now().Add(20 * time.Millisecond))
+	if err != nil || curr.Cmp(prev) <= 0 {
+		t.Fail()
+	}
+
+	// does not hold lock after returning
+	if !g.lock.TryLock() {
+		t.Fail()
+	}
+	g.lock.Unlock()
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fail()
+			}
+		}()
+		NewGenerator(WithoutLocking()).GenerateWithDeadline(time.Now().Add(time.Second))
+	}()
+}
+
+// Clones generator that resumes from the same monotonic state
+func TestClone(t *testing.T) {
+	var ts uint64 = 0x0123_4567_89ab
+	g := NewGenerator()
+	for i := 0; i < 1_000; i++ {
+		g.GenerateOrAbortCore(ts, 10_000)
+	}
+
+	c := g.Clone()
+	if c.timestamp != g.timestamp ||
+		c.counterHi != g.counterHi ||
+		c.counterLo != g.counterLo ||
+		c.tsCounterHi != g.tsCounterHi ||
+		c.rng != g.rng {
+		t.Fail()
+	}
+
+	x, _ := g.GenerateOrAbortCore(ts, 10_000)
+	y, _ := c.GenerateOrAbortCore(ts, 10_000)
+	if x.Timestamp() != y.Timestamp() ||
+		x.CounterHi() != y.CounterHi() ||
+		x.CounterLo() != y.CounterLo() {
+		t.Fail()
+	}
+
+	// diverges after clone
+	for i := 0; i < 1_000; i++ {
+		g.GenerateOrAbortCore(ts, 10_000)
+	}
+	z, _ := c.GenerateOrAbortCore(ts, 10_000)
+	if z.Cmp(y) <= 0 || c.counterLo == g.counterLo {
+		t.Fail()
+	}
+}
+
+// Reports increasing counter values within a single timestamp
+func TestStats(t *testing.T) {
+	var ts uint64 = 0x0123_4567_89ab
+	g := NewGenerator()
+
+	g.GenerateOrAbortCore(ts, 10_000)
+	prev := g.Stats()
+	if prev.Timestamp != ts ||
+		prev.CounterUtilization < 0 ||
+		prev.CounterUtilization > 1 {
+		t.Fail()
+	}
+
+	for i := 0; i < 1_000; i++ {
+		x, _ := g.GenerateOrAbortCore(ts, 10_000)
+		curr := g.Stats()
+		if curr.Timestamp != x.Timestamp() ||
+			curr.CounterHi != x.CounterHi() ||
+			curr.CounterLo != x.CounterLo() {
+			t.Fail()
+		}
+		if curr.Timestamp == prev.Timestamp &&
+			curr.CounterUtilization <= prev.CounterUtilization {
+			t.Fail()
+		}
+		prev = curr
+	}
+}
+
+// Reports decreasing remaining counter space within a single timestamp
+func TestRemainingThisMillis(t *testing.T) {
+	var ts uint64 = 0x0123_4567_89ab
+	g := NewGenerator()
+
+	g.GenerateOrAbortCore(ts, 10_000)
+	prev := g.RemainingThisMillis()
+	for i := 0; i < 1_000; i++ {
+		g.GenerateOrAbortCore(ts, 10_000)
+		curr := g.RemainingThisMillis()
+		if curr != prev-1 {
+			t.Fail()
+		}
+		prev = curr
+	}
+
+	g.counterHi = maxCounterHi
+	g.counterLo = maxCounterLo - 1
+	if g.RemainingThisMillis() != 1 {
+		t.Fail()
+	}
+	x, _ := g.GenerateOrAbortCore(ts, 10_000)
+	if g.RemainingThisMillis() != 0 || x.Timestamp() != ts {
+		t.Fail()
+	}
+	x, _ = g.GenerateOrAbortCore(ts, 10_000)
+	if x.Timestamp() != ts+1 {
+		t.Fail()
+	}
+}
+
+// Estimates counter exhaustion from counter_hi and time until its renewal
+func TestWouldExhaustAt(t *testing.T) {
+	if MaxRatePerMillis() != 281_474_959_933_441 {
+		t.Fail()
+	}
+
+	var ts uint64 = 0x0123_4567_89ab
+	g := NewGenerator()
+	if g.WouldExhaustAt(0) || g.WouldExhaustAt(1) || g.WouldExhaustAt(1_000_000) ||
+		!g.WouldExhaustAt(MaxRatePerMillis()) || !g.WouldExhaustAt(MaxRatePerMillis()+1) {
+		t.Fail()
+	}
+
+	// counter_hi has room for one increment in 1000 ms
+	g.generateWithEntropy(ts, 10_000, maxCounterHi-1, 0, 0)
+	if g.WouldExhaustAt(16_778) || !g.WouldExhaustAt(16_779) {
+		t.Fail()
+	}
+
+	// counter_hi has room for one increment in 1 ms
+	g.generateWithEntropy(ts+999, 10_000, 0, 0, 0)
+	if g.WouldExhaustAt(1<<24+1) || !g.WouldExhaustAt(1<<24+2) {
+		t.Fail()
+	}
+
+	// counter_hi renewed with maximum value has no room
+	g.generateWithEntropy(ts+1_000, 10_000, maxCounterHi, 0, 0)
+	if !g.WouldExhaustAt(2) || g.WouldExhaustAt(1) {
+		t.Fail()
+	}
+}
+
+// Flags IDs generated after generator reset
+func TestWasLikelyReset(t *testing.T) {
+	var ts uint64 = 0x0123_4567_89ab
+	g := NewGenerator()
+
+	prev, _ := g.GenerateOrResetCore(ts, 10_000)
+	for _, e := range []uint64{ts, ts + 1, ts - 9_000, ts + 5} {
+		curr, _ := g.GenerateOrResetCore(e, 10_000)
+		if WasLikelyReset(prev, curr) {
+			t.Fail()
+		}
+		prev = curr
+	}
+
+	// significant rollback resets generator
+	curr, _ := g.GenerateOrResetCore(ts-10_000, 10_000)
+	if !WasLikelyReset(prev, curr) || WasLikelyReset(curr, prev) {
+		t.Fail()
+	}
+	prev = curr
+	curr, _ = g.GenerateOrResetCore(ts-10_000, 10_000)
+	if WasLikelyReset(prev, curr) {
+		t.Fail()
+	}
+}
+
+// Reports generator reset upon significant clock rollback
+func TestGenerateReportReset(t *testing.T) {
+	g := NewGenerator()
+	prev, reset, err := g.GenerateReportReset()
+	if reset || err != nil {
+		t.Fail()
+	}
+	for i := 0; i < 1_000; i++ {
+		curr, reset, err := g.GenerateReportReset()
+		if reset || err != nil || prev.Cmp(curr) >= 0 {
+			t.Fail()
+		}
+		prev = curr
+	}
+
+	// emulate clock rollback by pushing generator state ahead
+	g.timestamp += 10_000
+	curr, reset, err := g.GenerateReportReset()
+	if reset || err != nil || prev.Cmp(curr) >= 0 {
+		t.Fail()
+	}
+	prev = curr
+
+	g.timestamp += 10_000
+	curr, reset, err = g.GenerateReportReset()
+	if !reset || err != nil || prev.Cmp(curr) <= 0 {
+		t.Fail()
+	}
+	prev = curr
+
+	curr, reset, err = g.GenerateReportReset()
+	if reset || err != nil || prev.Cmp(curr) >= 0 {
+		t.Fail()
+	}
+}
+
+// Generates IDs from timestamps passed in a thread-safe manner
+func TestGenerateForTimestamp(t *testing.T) {
+	var ts uint64 = 0x0123_4567_89ab
+	g := NewGenerator()
+
+	prev, err := g.GenerateForTimestamp(ts)
+	if err != nil || prev.Timestamp() != ts {
+		t.Fail()
+	}
+	for i := uint64(1); i < 1_000; i++ {
+		curr, err := g.GenerateForTimestamp(ts + i*10)
+		if err != nil || curr.Timestamp() != ts+i*10 || prev.Cmp(curr) >= 0 {
+			t.Fail()
+		}
+		prev = curr
+	}
+
+	curr, err := g.GenerateForTimestamp(prev.Timestamp() - 10_000)
+	if err != nil || curr.Timestamp() != prev.Timestamp() || prev.Cmp(curr) >= 0 {
+		t.Fail()
+	}
+	_, err = g.GenerateForTimestamp(prev.Timestamp() - 10_001)
+	if err != ErrClockRollback {
+		t.Fail()
+	}
+
+	// thread-safe
+	group := new(sync.WaitGroup)
+	results := make(chan Id, 4*1_000)
+	for i := 0; i < 4; i++ {
+		group.Add(1)
+		go func() {
+			defer group.Done()
+			for i := uint64(0); i < 1_000; i++ {
+				x, _ := g.GenerateForTimestamp(ts + 20_000 + i)
+				results <- x
+			}
+		}()
+	}
+	group.Wait()
+	close(results)
+	set := make(map[Id]struct{})
+	for e := range results {
+		set[e] = struct{}{}
+	}
+	if len(set) != 4*1_000 {
+		t.Fail()
+	}
+}
+
+// Generates increasing IDs from increasing nanosecond times
+func TestGenerateForUnixNano(t *testing.T) {
+	base := time.Now().Truncate(time.Millisecond).UnixNano()
+	g := NewGenerator()
+
+	prev, err := g.GenerateForUnixNano(base)
+	if err != nil || prev.Timestamp() != uint64(base/1_000_000) {
+		t.Fail()
+	}
+	for _, e := range []int64{1, 2, 1_000, 500_000, 999_999, 1_000_000, 1_500_000, 2_000_000} {
+		curr, err := g.GenerateForUnixNano(base + e)
+		if err != nil || curr.Timestamp() != uint64((base+e)/1_000_000) || prev.Cmp(curr) >= 0 {
+			t.Fail()
+		}
+		prev = curr
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fail()
+			}
+		}()
+		g.GenerateForUnixNano(-1)
+	}()
+}
+
+// Generates IDs with strictly increasing timestamps
+func TestGenerateNewMillis(t *testing.T) {
+	g := NewGenerator()
+	prev, err := g.GenerateNewMillis()
+	if err != nil {
+		t.Fail()
+	}
+	for i := 0; i < 20; i++ {
+		if i%2 == 0 {
+			g.Generate()
+		}
+		curr, err := g.GenerateNewMillis()
+		if err != nil || curr.Timestamp() <= prev.Timestamp() ||
+			uint64(time.Now().UnixMilli()) < curr.Timestamp() {
+			t.Fail()
+		}
+		prev = curr
+	}
+}
+
+// Generates string equivalent to Generate().String()
+func TestGenerateString(t *testing.T) {
+	matched := 0
+	for i := 0; i < 10; i++ {
+		g, h := NewInsecureCountingGenerator(), NewInsecureCountingGenerator()
+		s, err := g.GenerateString()
+		if err != nil {
+			t.Fatal(err)
+		}
+		u, err := g.GenerateStringOrAbort()
+		if err != nil {
+			t.Fatal(err)
+		}
+		x, err := h.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+		y, err := h.GenerateOrAbort()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u <= s {
+			t.Fail()
+		}
+
+		// timestamps may differ if a millisecond boundary is crossed
+		if s == x.String() && u == y.String() {
+			matched++
+		}
+	}
+
+	if matched == 0 {
+		t.Fail()
+	}
+}
+
+// Generates byte array equivalent to Generate()
+func TestGenerateBytes(t *testing.T) {
+	matched := 0
+	for i := 0; i < 10; i++ {
+		g, h := NewInsecureCountingGenerator(), NewInsecureCountingGenerator()
+		b, err := g.GenerateBytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		x, err := h.Generate()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// timestamps may differ if a millisecond boundary is crossed
+		if bin, _ := x.MarshalBinary(); bytes.Equal(b[:], bin) {
+			matched++
+		}
+	}
+
+	if matched == 0 {
+		t.Fail()
+	}
+}
+
+// Generates IDs that sort after given IDs
+func TestGenerateAfter(t *testing.T) {
+	now := uint64(time.Now().UnixMilli())
+	cases := []Id{
+		FromFields(0, 0, 0, 0),
+		FromFields(now-60_000, maxCounterHi, maxCounterLo, maxUint32),
+		FromFields(now, 0, 0, 0),
+		FromFields(now, maxCounterHi, maxCounterLo-1, maxUint32),
+		FromFields(now+1_000, 0x123456, 0x789abc, 0),
+		FromFields(now+5_000, maxCounterHi, maxCounterLo, maxUint32),
+	}
+
+	for _, prev := range cases {
+		g := NewGenerator()
+		for i := 0; i < 1_000; i++ {
+			curr, err := g.GenerateAfter(prev)
+			if err != nil || prev.Cmp(curr) >= 0 ||
+				prev.Timestamp() > curr.Timestamp() ||
+				(prev.Timestamp() == curr.Timestamp() &&
+					prev.CounterHi() > curr.CounterHi()) ||
+				(prev.Timestamp() == curr.Timestamp() &&
+					prev.CounterHi() == curr.CounterHi() &&
+					prev.CounterLo() >= curr.CounterLo()) {
+				t.Fail()
+			}
+			prev = curr
+		}
+	}
+
+	g := NewGenerator()
+	_, err := g.GenerateAfter(FromFields(now+60_000, 0, 0, 0))
+	if err != ErrClockRollback {
+		t.Fail()
+	}
+}
+
+// Regenerates ID while reported as seen
+func TestGenerateUnique(t *testing.T) {
+	g := NewGenerator()
+	var rejected []Id
+	x, err := g.GenerateUnique(func(id Id) bool {
+		if len(rejected) == 0 {
+			rejected = append(rejected, id)
+			return true
+		}
+		return false
+	})
+	if err != nil || len(rejected) != 1 || x.Cmp(rejected[0]) <= 0 {
+		t.Fail()
+	}
+
+	calls := 0
+	_, err = g.GenerateUnique(func(id Id) bool {
+		calls++
+		return true
+	})
+	if err != ErrTooManyDuplicates || calls != maxUniqueAttempts {
+		t.Fail()
+	}
+}
+
+// Reserves consecutive counter values from which caller derives unique IDs
+func TestReserveBlock(t *testing.T) {
+	g := NewGenerator()
+	prev, _ := g.Generate()
+	seen := make(map[Id]struct{})
+	for _, n := range []uint32{1, 2, 1_000, 65_536} {
+		first, err := g.ReserveBlock(n)
+		if err != nil || first.Cmp(prev) <= 0 {
+			t.Fail()
+		}
+		for i := uint32(0); i < n; i++ {
+			x := FromUint128(first.ToUint128().Add(Uint128{Lo: uint64(i) << 32}))
+			if x.Timestamp() != first.Timestamp() || x.Entropy() != first.Entropy() {
+				t.Fail()
+			}
+			if x.Cmp(prev) <= 0 {
+				t.Fail()
+			}
+			if _, ok := seen[x]; ok {
+				t.Fail()
+			}
+			seen[x] = struct{}{}
+			prev = x
+		}
+	}
+	if x, _ := g.Generate(); x.Cmp(prev) <= 0 {
+		t.Fail()
+	}
+
+	// moves on to next timestamp if remaining counter space is insufficient
+	ts := g.timestamp + 1_000
+	g.timestamp = ts
+	g.tsCounterHi = ts
+	g.counterHi = maxCounterHi
+	g.counterLo = maxCounterLo - 10
+	first, err := g.ReserveBlock(100)
+	if err != nil || first.Timestamp() != ts+1 {
+		t.Fail()
+	}
+	last := FromUint128(first.ToUint128().Add(Uint128{Lo: 99 << 32}))
+	if last.Timestamp() != ts+1 || last.ToUint128().Sub(first.ToUint128()) != (Uint128{Lo: 99 << 32}) {
+		t.Fail()
+	}
+	if x, _ := g.Generate(); x.Cmp(last) <= 0 {
+		t.Fail()
+	}
+
+	// moves timestamp only once in strict mode and counts one generated ID
+	m := &fakeMetrics{}
+	g = NewGenerator(WithStrictTimestampMonotonicity(), WithMetrics(m))
+	g.Generate()
+	ts = g.timestamp
+	g.counterHi = maxCounterHi
+	first, err = g.ReserveBlock(maxBlockSize)
+	if err != nil || first.Timestamp() > ts+2 || g.timestamp != first.Timestamp() {
+		t.Fail()
+	}
+	if m.generated != 2 || m.rollback != 0 || m.counterOverflow > 1 {
+		t.Fail()
+	}
+
+	for _, n := range []uint32{0, maxBlockSize + 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fail()
+				}
+			}()
+			g.ReserveBlock(n)
+		}()
+	}
+}
+
+// Draws random values from prefetched buffer until depleted
+func TestPrefetchEntropy(t *testing.T) {
+	var ts uint64 = 0x0123_4567_89ab
+	rng := &countingReader{r: crand.Reader}
+	g := NewGeneratorWithRng(rng)
+	if g.PrefetchEntropy(10) != nil || len(g.prefetched) != 80 {
+		t.Fail()
+	}
+	count := rng.count
+
+	// first ID consumes three values and the others one each
+	prev, _ := g.GenerateOrAbortCore(ts, 10_000)
+	for i := 0; i < 17; i++ {
+		curr, err := g.GenerateOrAbortCore(ts, 10_000)
+		if err != nil || curr.Cmp(prev) <= 0 {
+			t.Fail()
+		}
+		prev = curr
+	}
+	if rng.count != count || g.prefetched != nil {
+		t.Fail()
+	}
+	g.GenerateOrAbortCore(ts, 10_000)
+	if rng.count != count+1 {
+		t.Fail()
+	}
+
+	// appends to remaining buffer
+	g.PrefetchEntropy(1)
+	g.GenerateOrAbortCore(ts, 10_000)
+	g.PrefetchEntropy(1)
+	if len(g.prefetched) != 12 {
+		t.Fail()
+	}
+	if h := g.Clone(); h.prefetched != nil {
+		t.Fail()
+	}
+
+	// discards buffer upon rng swap
+	buffer := g.prefetched
+	g.SetRng(rng)
+	if g.prefetched != nil || !bytes.Equal(buffer, make([]byte, 12)) {
+		t.Fail()
+	}
+
+	// leaves buffer unchanged upon error
+	g = NewGeneratorWithRng(bytes.NewReader(make([]byte, 12)))
+	if g.PrefetchEntropy(1) != nil || g.PrefetchEntropy(1) == nil || len(g.prefetched) != 8 {
+		t.Fail()
+	}
+
+	for _, n := range []int{-1, maxPrefetch + 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fail()
+				}
+			}()
+			g.PrefetchEntropy(n)
+		}()
+	}
+}
+
+// Seeds counter_hi in advance
+func TestWarmup(t *testing.T) {
+	rng := &countingReader{r: crand.Reader}
+	g := NewGeneratorWithRng(rng)
+	if g.Warmup() != nil || g.tsCounterHi == 0 || rng.count != 1 {
+		t.Fail()
+	}
+	counterHi := g.counterHi
+
+	// no-op after warmup
+	if g.Warmup() != nil || rng.count != 1 {
+		t.Fail()
+	}
+
+	// first ID reads only counter_lo and entropy
+	x, err := g.Generate()
+	if err != nil || rng.count != 3 || x.CounterHi() != counterHi {
+		t.Fail()
+	}
+
+	g = NewGeneratorWithRng(bytes.NewReader(nil))
+	if g.Warmup() == nil || g.tsCounterHi != 0 {
+		t.Fail()
+	}
+}
+
 func BenchmarkGeneratorDefault(b *testing.B) {
 	g := NewGenerator()
 	b.ResetTimer()
@@ -2,14 +2,38 @@ package scru128
 
 import (
 	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"io"
+	"math/bits"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Represents a SCRU128 ID and provides converters and comparison operators.
 type Id [16]byte
 
+// The smallest possible SCRU128 ID, with all 128 bits unset.
+var Nil = Id{}
+
+// The largest possible SCRU128 ID, with all 128 bits set.
+var Max = Id{
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+}
+
+// The maximum value of the 32-bit entropy field.
+const MaxEntropy = 0xffff_ffff
+
 // Creates a SCRU128 ID object from field values.
 //
+// The entropy field is a full 32-bit value, so any `entropy` is valid; use
+// [FromFieldsStrict] to validate every field explicitly.
+//
 // This function panics if any argument is out of the value range of the field.
 func FromFields(
 	timestamp uint64,
@@ -43,12 +67,299 @@ func FromFields(
 	}
 }
 
+// Creates a SCRU128 ID object from field values given as uint64, validating
+// each of them against the width of the field, including the entropy field
+// against [MaxEntropy].
+//
+// This function is a variant of [FromFields] for untrusted inputs and code
+// that should make the range of every field explicit. It returns a non-nil err
+// instead of panicking if any argument is out of the value range of the field.
+func FromFieldsStrict(timestamp, counterHi, counterLo, entropy uint64) (Id, error) {
+	if timestamp > maxTimestamp {
+		return Id{}, fmt.Errorf(
+			"scru128.Id: `timestamp` out of 48-bit range: %d", timestamp)
+	} else if counterHi > uint64(maxCounterHi) {
+		return Id{}, fmt.Errorf(
+			"scru128.Id: `counterHi` out of 24-bit range: %d", counterHi)
+	} else if counterLo > uint64(maxCounterLo) {
+		return Id{}, fmt.Errorf(
+			"scru128.Id: `counterLo` out of 24-bit range: %d", counterLo)
+	} else if entropy > MaxEntropy {
+		return Id{}, fmt.Errorf(
+			"scru128.Id: `entropy` out of 32-bit range: %d", entropy)
+	}
+	return FromFields(
+		timestamp, uint32(counterHi), uint32(counterLo), uint32(entropy)), nil
+}
+
+// Creates a SCRU128 ID object from the timestamp of `t` (truncated to
+// milliseconds) and the other field values.
+//
+// This function is a variant of [FromFields] that is friendlier for
+// reproducing IDs from logs and other human-readable sources, where the time
+// is more readily available than the Unix timestamp in milliseconds.
+//
+// This function returns a non-nil err, instead of panicking, if `t` is before
+// the Unix epoch or beyond the 48-bit timestamp range or if `counterHi` or
+// `counterLo` is out of the 24-bit range.
+func FromFieldsAt(t time.Time, counterHi, counterLo, entropy uint32) (Id, error) {
+	ms := t.UnixMilli()
+	if ms < 0 || uint64(ms) > maxTimestamp {
+		return Id{}, fmt.Errorf("scru128.Id: `t` out of 48-bit timestamp range: %v", t)
+	} else if counterHi > maxCounterHi {
+		return Id{}, fmt.Errorf(
+			"scru128.Id: `counterHi` out of 24-bit range: %d", counterHi)
+	} else if counterLo > maxCounterLo {
+		return Id{}, fmt.Errorf(
+			"scru128.Id: `counterLo` out of 24-bit range: %d", counterLo)
+	}
+	return FromFields(uint64(ms), counterHi, counterLo, entropy), nil
+}
+
+// Creates a deterministic SCRU128 ID object from `namespace` and `name`, in a
+// manner similar to UUIDv5, by filling all the 128 bits, including the
+// timestamp field, with the first 16 bytes of the SHA-256 digest of the
+// concatenation of `namespace` and `name`.
+//
+// This function derives stable IDs from external keys: the same inputs always
+// yield the same ID. Note that the resulting IDs are NOT time-ordered and their
+// timestamp fields carry no meaningful time, so they should be kept in a
+// separate space from the IDs generated by [Generator].
+func FromName(namespace Id, name []byte) Id {
+	h := sha256.New()
+	h.Write(namespace[:])
+	h.Write(name)
+	var id Id
+	copy(id[:], h.Sum(nil))
+	return id
+}
+
+// Creates a SCRU128 ID object from a timestamp, with zero counter_hi and
+// counter_lo fields and a random entropy field read from crypto/rand.
+//
+// This is a convenience for constructing IDs where only the timestamp matters,
+// e.g., in tests. Use [Generator] to generate monotonically increasing IDs.
+//
+// This function returns a non-nil err if `timestamp` is not a 48-bit unsigned
+// integer or crypto/rand fails.
+func FromTimestamp(timestamp uint64) (Id, error) {
+	if timestamp > maxTimestamp {
+		return Id{}, fmt.Errorf(
+			"scru128.Id: `timestamp` out of 48-bit range: %d", timestamp)
+	}
+	var entropy [4]byte
+	if _, err := io.ReadFull(rand.Reader, entropy[:]); err != nil {
+		return Id{}, fmt.Errorf(
+			"scru128.Id: random number generator error: %w", err)
+	}
+	return FromFields(timestamp, 0, 0, uint32(bytesToUint64(entropy[:]))), nil
+}
+
+// Creates a SCRU128 ID object from a pair of uint64 values representing the
+// upper and lower 64 bits of the 128-bit unsigned integer.
+//
+// This is the inverse of [Id.Uint64Pair].
+func FromUint64Pair(hi, lo uint64) Id {
+	var bs Id
+	for i := 0; i < 8; i++ {
+		bs[i] = byte(hi >> (56 - 8*i))
+		bs[8+i] = byte(lo >> (56 - 8*i))
+	}
+	return bs
+}
+
+// Returns the smallest SCRU128 ID with the timestamp of `t` (truncated to
+// milliseconds), i.e., one whose counter_hi, counter_lo, and entropy fields are
+// all zero.
+//
+// Combined with [MaxForTime], this function helps build range queries such as
+// "WHERE id BETWEEN MinForTime(t1) AND MaxForTime(t2)" that select all IDs
+// generated from `t1` through `t2`.
+//
+// This function panics if `t` is before the Unix epoch or beyond the 48-bit
+// timestamp range.
+func MinForTime(t time.Time) Id {
+	return FromFields(timeToTimestamp(t), 0, 0, 0)
+}
+
+// Returns the largest SCRU128 ID with the timestamp of `t` (truncated to
+// milliseconds), i.e., one whose counter_hi, counter_lo, and entropy fields are
+// all set to their maximum values.
+//
+// See [MinForTime] for the usage.
+//
+// This function panics if `t` is before the Unix epoch or beyond the 48-bit
+// timestamp range.
+func MaxForTime(t time.Time) Id {
+	return FromFields(timeToTimestamp(t), maxCounterHi, maxCounterLo, 0xffff_ffff)
+}
+
+// Represents a contiguous range of SCRU128 IDs from `Lo` through `Hi`, both
+// inclusive.
+type IdRange struct {
+	Lo, Hi Id
+}
+
+// Divides the time range [start, end) into `shards` contiguous and disjoint ID
+// ranges, e.g., to let each worker of a pool scan a disjoint range in
+// parallel.
+//
+// The shards are divided by time, not by the number of IDs: each shard covers
+// a time span of nearly equal length in milliseconds, from [MinForTime] of the
+// first millisecond through [MaxForTime] of the last, so the shards may contain
+// very different numbers of IDs. `start` and `end` are truncated to
+// milliseconds as in [MinForTime]. This function returns fewer ranges than
+// `shards` if the range spans fewer milliseconds, and nil if `end` is not
+// after `start`.
+//
+// This function panics if `shards` is not positive or if `start` or `end` is
+// before the Unix epoch or beyond the 48-bit timestamp range.
+func TimeRangeShards(start, end time.Time, shards int) []IdRange {
+	if shards <= 0 {
+		panic("`shards` must be positive")
+	}
+	startMs, endMs := timeToTimestamp(start), timeToTimestamp(end)
+	if endMs <= startMs {
+		return nil
+	}
+	span := endMs - startMs
+	n := uint64(shards)
+	if n > span {
+		n = span
+	}
+
+	ranges := make([]IdRange, n)
+	lo := startMs
+	for i := range ranges {
+		// hi = startMs + span * (i + 1) / n (exclusive)
+		h, l := bits.Mul64(span, uint64(i+1))
+		q, _ := bits.Div64(h, l, n)
+		hi := startMs + q
+		ranges[i] = IdRange{
+			FromFields(lo, 0, 0, 0),
+			FromFields(hi-1, maxCounterHi, maxCounterLo, 0xffff_ffff),
+		}
+		lo = hi
+	}
+	return ranges
+}
+
+// Converts a time.Time into a 48-bit timestamp, or panics if out of range.
+func timeToTimestamp(t time.Time) uint64 {
+	ms := t.UnixMilli()
+	if ms < 0 || uint64(ms) > maxTimestamp {
+		panic("`t` out of 48-bit timestamp range")
+	}
+	return uint64(ms)
+}
+
 // Creates a SCRU128 ID object from a 25-digit string representation.
 func Parse(strValue string) (id Id, err error) {
 	err = id.UnmarshalText([]byte(strValue))
 	return
 }
 
+// Creates a SCRU128 ID object from a 25-digit string representation in the
+// canonical form, i.e., with lowercase letters only.
+//
+// Unlike [Parse], which accepts uppercase letters as well, this function
+// returns an error wrapping [ErrInvalidDigit] if `s` contains an uppercase
+// letter. This helps enforce a single representation of IDs in storage.
+func ParseStrict(s string) (id Id, err error) {
+	if len(s) == StringLen {
+		for i := 0; i < len(s); i++ {
+			if 'A' <= s[i] && s[i] <= 'Z' {
+				return Id{}, newParseError(&InvalidDigitError{Pos: i, Char: s[i]})
+			}
+		}
+	}
+	return Parse(s)
+}
+
+// Reports whether `s` looks like a SCRU128 ID string, i.e., whether it consists
+// of exactly 25 case-insensitive Base36 digits.
+//
+// This is a cheap heuristic to choose a parser when both SCRU128 IDs and UUIDs
+// (36-character hyphenated or 32-character hexadecimal strings) are accepted.
+// It does not check the 128-bit value range, so [Parse] may still fail for a
+// string for which this function returns true.
+func LooksLikeScru128(s string) bool {
+	if len(s) != StringLen {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if decodeMap[s[i]] == 0xff {
+			return false
+		}
+	}
+	return true
+}
+
+// Encodes `id` into the 25-digit canonical string representation and decodes
+// the result back, returning the decoded ID.
+//
+// This function standardizes a property test for downstream users: the result
+// must always equal `id`, and this function returns an error if decoding fails
+// or if the result does not equal `id`, either of which indicates a bug.
+func RoundTripString(id Id) (Id, error) {
+	return roundTripString(id, Id.String)
+}
+
+// Implements [RoundTripString] with a replaceable `encode` function so that the
+// failure paths can be tested with a faulty encoder.
+func roundTripString(id Id, encode func(Id) string) (Id, error) {
+	decoded, err := Parse(encode(id))
+	if err != nil {
+		return decoded, err
+	} else if decoded != id {
+		return decoded, fmt.Errorf(
+			"scru128.Id: round trip mismatch: %v decoded from %v", decoded, id)
+	}
+	return decoded, nil
+}
+
+// Returns the canonical (lowercase) form of the 25-digit string representation
+// `s`, or an error if `s` is not a valid SCRU128 ID string.
+//
+// This function combines validation and normalization of user input in one
+// call.
+func Normalize(s string) (string, error) {
+	id, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return string(id.AppendString(make([]byte, 0, StringLen))), nil
+}
+
+// Creates a SCRU128 ID object from a 25-digit string representation read from
+// `r`, consuming exactly 25 bytes.
+//
+// This function is useful when IDs are streamed as fixed-width tokens. It
+// returns an error wrapping [ErrInvalidLength] and io.ErrUnexpectedEOF or
+// io.EOF if `r` ends before 25 bytes are read, or the error returned by `r` if
+// reading fails otherwise.
+func ParseReader(r io.Reader) (id Id, err error) {
+	var text [StringLen]byte
+	n, err := io.ReadFull(r, text[:])
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return Id{}, newParseError(fmt.Errorf(
+			"%w: %d bytes (expected 25): %w", ErrInvalidLength, n, err))
+	} else if err != nil {
+		return Id{}, err
+	}
+	err = id.UnmarshalText(text[:])
+	return
+}
+
+// Creates a SCRU128 ID object from a pair of int64 values returned by
+// [Id.SQLPair], e.g., those read from two BIGINT columns.
+//
+// This is the inverse of [Id.SQLPair]; negative values are reinterpreted as
+// the uint64 values with the same bit patterns.
+func FromSQLPair(hi, lo int64) Id {
+	return FromUint64Pair(uint64(hi), uint64(lo))
+}
+
 // Returns the 48-bit timestamp field value.
 func (bs Id) Timestamp() uint64 {
 	return bytesToUint64(bs[0:6])
@@ -69,18 +380,432 @@ func (bs Id) Entropy() uint32 {
 	return uint32(bytesToUint64(bs[12:16]))
 }
 
-// Returns the 25-digit canonical string representation.
+// Returns a copy of the object with the entropy field replaced by `entropy`,
+// keeping the timestamp, counter_hi, and counter_lo fields unchanged.
+//
+// This method helps construct IDs that share the timestamp and counters by
+// hand, e.g., in tests; such IDs sort in the order of `entropy`.
+func (bs Id) WithEntropy(entropy uint32) Id {
+	bs[12] = byte(entropy >> 24)
+	bs[13] = byte(entropy >> 16)
+	bs[14] = byte(entropy >> 8)
+	bs[15] = byte(entropy)
+	return bs
+}
+
+// Returns the upper and lower 64 bits of the 128-bit unsigned integer.
+//
+// The upper half consists of the 48-bit timestamp and the upper 16 bits of the
+// counter_hi field, and the lower half consists of the remaining bits, both in
+// the big-endian byte order consistent with the byte array representation.
+func (bs Id) Uint64Pair() (hi, lo uint64) {
+	return bytesToUint64(bs[0:8]), bytesToUint64(bs[8:16])
+}
+
+// Returns the upper and lower 64 bits of the 128-bit unsigned integer
+// reinterpreted as int64 values for storage in two signed integer columns,
+// e.g., BIGINT, of databases that lack unsigned integer types.
+//
+// The bit patterns are kept as is, so a half whose most significant bit is set
+// becomes a negative int64 value. The upper half stays non-negative until the
+// timestamp reaches 2^47 milliseconds (around year 6429), but the lower half
+// is negative for about half of IDs; hence, ordering the columns as signed
+// integers does NOT preserve the order of IDs unless the lower half is
+// compared as unsigned. Use [FromSQLPair] to convert the values back.
+func (bs Id) SQLPair() (hi, lo int64) {
+	uhi, ulo := bs.Uint64Pair()
+	return int64(uhi), int64(ulo)
+}
+
+// Returns the timestamp field value as a time.Time in UTC.
+func (bs Id) Time() time.Time {
+	return time.UnixMilli(int64(bs.Timestamp())).UTC()
+}
+
+// Returns the timestamp field value measured from `epoch` in Unix milliseconds
+// as a time.Time in UTC.
+//
+// This method decodes the IDs generated by a generator configured by
+// [WithEpoch] with the same `epoch`.
+func (bs Id) TimeWithEpoch(epoch uint64) time.Time {
+	return time.UnixMilli(int64(epoch + bs.Timestamp())).UTC()
+}
+
+// Returns true if the timestamp of the object falls within the half-open
+// interval [start, end), i.e., if `start` <= [Id.Time] < `end`.
+//
+// A zero `start` or `end` (i.e., time.Time{}) leaves the respective side of
+// the interval unbounded. Note that the timestamp has millisecond precision,
+// so an ID generated in the same millisecond as a sub-millisecond `start` may
+// be reported as out of the range.
+func (bs Id) InTimeRange(start, end time.Time) bool {
+	t := bs.Time()
+	return (start.IsZero() || !t.Before(start)) && (end.IsZero() || t.Before(end))
+}
+
+// Returns the time elapsed since the timestamp of the object, i.e.,
+// time.Since([Id.Time]).
+//
+// The result depends on the current wall clock and may be negative if the ID
+// was generated on a host whose clock is ahead of the local one.
+func (bs Id) Age() time.Duration {
+	return time.Since(bs.Time())
+}
+
+// Returns the difference between the timestamp fields of the object and
+// `other`, i.e., [Id.Time] of the object minus that of `other`, e.g., the
+// latency between two correlated events.
+//
+// The result has millisecond resolution and is negative if `other` has a
+// greater timestamp. Like time.Time.Sub, the result saturates at the minimum
+// or maximum time.Duration if the difference exceeds its range of about 292
+// years.
+func (bs Id) Sub(other Id) time.Duration {
+	return bs.Time().Sub(other.Time())
+}
+
+// Returns the timestamp floored to a multiple of `d` counted from the Unix
+// epoch, e.g., the start of the hour that contains the timestamp if `d` is
+// time.Hour, as a time.Time in UTC.
+//
+// Unlike time.Time.Truncate, which counts from the zero time, the buckets are
+// aligned to the Unix epoch for any `d`. This method panics if `d` is not
+// positive.
+func (bs Id) Bucket(d time.Duration) time.Time {
+	if d <= 0 {
+		panic("scru128.Id: non-positive bucket duration")
+	}
+
+	// compute in 128-bit nanoseconds because 48-bit milliseconds do not fit in
+	// int64 nanoseconds
+	hi, lo := bits.Mul64(bs.Timestamp(), uint64(time.Millisecond))
+	_, rem := bits.Div64(0, hi, uint64(d))
+	_, rem = bits.Div64(rem, lo, uint64(d))
+	lo, borrow := bits.Sub64(lo, rem, 0)
+	hi -= borrow
+	sec, nsec := bits.Div64(hi, lo, uint64(time.Second))
+	return time.Unix(int64(sec), int64(nsec)).UTC()
+}
+
+// Returns the 25-digit canonical string representation, or its uppercase
+// variant if enabled by [SetStringCase].
 func (bs Id) String() string {
-	buffer, _ := bs.MarshalText()
+	buffer := bs.AppendString(make([]byte, 0, StringLen))
+	if upperString.Load() {
+		for i, e := range buffer {
+			if e >= 'a' {
+				buffer[i] = e - 'a' + 'A'
+			}
+		}
+	}
 	return string(buffer)
 }
 
+// Returns the 25-digit canonical string representation as a key for maps keyed
+// by string.
+//
+// Unlike [Id.String], this method always returns the canonical lowercase form
+// regardless of [SetStringCase], so that the keys of the same ID never differ
+// in case. Note that Id is comparable and can be used as a map key by itself,
+// which is more efficient where string keys are not required.
+func (bs Id) MapKey() string {
+	return string(bs.AppendString(make([]byte, 0, StringLen)))
+}
+
+// Returns the first 10 digits of the 25-digit canonical string representation
+// as a coarse, fixed-width, and sortable key, e.g., for sharding or secondary
+// indexes.
+//
+// The 10 digits encode the 48-bit timestamp and the top two or three bits of
+// counter_hi; a key corresponds to a range of about 0.18 milliseconds that is
+// not aligned to millisecond boundaries, so the IDs generated within the same
+// millisecond have at most seven distinct keys, and the IDs of adjacent
+// milliseconds may share a key. The key of an ID generated later is
+// lexicographically greater than or equal to that of an ID generated earlier
+// by the same generator.
+func (bs Id) TimeKey() string {
+	return string(bs.AppendString(make([]byte, 0, StringLen))[:10])
+}
+
+// Returns a 25-character representation for logging in which the last seven
+// digits, which depend on the entropy field, are masked with asterisks, e.g.,
+// "036z8puq4tsxsigk6o*******".
+//
+// The result is computed from the ID with the entropy field zeroed, so IDs that
+// share the timestamp and counters are redacted to the same string, and the
+// unmasked prefix preserves the order of IDs, though IDs that differ only in
+// the lower bits of counter_lo may be redacted to the same string as well. The
+// redaction is lossy and the result cannot be parsed back into an ID.
+func (bs Id) RedactedString() string {
+	copy(bs[12:16], []byte{0, 0, 0, 0})
+	text := bs.AppendString(make([]byte, 0, StringLen))
+	copy(text[18:], "*******")
+	return string(text)
+}
+
+// Returns the 32-bit entropy field value encoded in seven Base36 digits
+// (zero-padded lowercase), e.g., as a short random token derived from an ID.
+//
+// The token can be decoded with strconv.ParseUint(token, 36, 32). Note that
+// the token is NOT unique on its own: the 32-bit entropy field is expected to
+// collide among about 77,000 IDs with a probability of 50%, and IDs generated
+// with [WithNodeBits] share the top bits of the entropy field.
+func (bs Id) EntropyToken() string {
+	var buffer [7]byte
+	n := bs.Entropy()
+	for i := len(buffer) - 1; i >= 0; i-- {
+		buffer[i] = digits[n%36]
+		n /= 36
+	}
+	return string(buffer[:])
+}
+
+// Returns a human-readable representation of the field values for debugging,
+// e.g., "timestamp=1690000000000 (2023-07-22T04:26:40.000Z), counter_hi=1,
+// counter_lo=2, entropy=3".
+func (bs Id) Dump() string {
+	return fmt.Sprintf(
+		"timestamp=%d (%s), counter_hi=%d, counter_lo=%d, entropy=%d",
+		bs.Timestamp(),
+		bs.Time().Format("2006-01-02T15:04:05.000Z07:00"),
+		bs.CounterHi(),
+		bs.CounterLo(),
+		bs.Entropy(),
+	)
+}
+
+// See fmt.Formatter
+//
+// This method formats the object as follows:
+//
+//   - %v and %s: the 25-digit canonical string representation.
+//   - %+v: the field values, e.g., "{timestamp:1690000000000 counterHi:1
+//     counterLo:2 entropy:3}", for debugging.
+//   - %#v: the Go-syntax representation of the byte array, e.g.,
+//     "scru128.Id{0x1, 0x89, ...}".
+//   - %q, %x, and %X: applied to the canonical string representation.
+//   - Other verbs (e.g., %d): applied to the byte array as without this
+//     method, e.g., "[0 1 137 ...]".
+//
+// Width and other flags are applied to the resulting string.
+func (bs Id) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprintf(f, "{timestamp:%d counterHi:%d counterLo:%d entropy:%d}",
+			bs.Timestamp(), bs.CounterHi(), bs.CounterLo(), bs.Entropy())
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprint(f, "scru128.Id"+strings.TrimPrefix(fmt.Sprintf("%#v", [16]byte(bs)), "[16]uint8"))
+	case verb == 'v', verb == 's', verb == 'q', verb == 'x', verb == 'X':
+		fmt.Fprintf(f, fmt.FormatString(f, verb), bs.String())
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, verb), [16]byte(bs))
+	}
+}
+
+// Reports whether `s` is a 25-digit string representation of the object.
+//
+// The comparison is case-insensitive, like [Parse], so both the canonical
+// lowercase form and its uppercase variant match. This method encodes the
+// object into a stack buffer instead of parsing `s` or allocating a string, so
+// it is cheaper than `bs.String() == s`, especially when the result is false.
+func (bs Id) EqualString(s string) bool {
+	if len(s) != StringLen {
+		return false
+	}
+	var buffer [StringLen]byte
+	text := bs.AppendString(buffer[:0])
+	for i, e := range text {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c != e {
+			return false
+		}
+	}
+	return true
+}
+
 // Returns -1, 0, or 1 if the object is less than, equal to, or greater than the
 // argument, respectively.
 func (bs Id) Cmp(other Id) int {
 	return bytes.Compare(bs[:], other[:])
 }
 
+// Returns -1, 0, or 1 if the object is less than, equal to, or greater than the
+// argument, respectively.
+//
+// This method is equivalent to [Id.Cmp] but takes pointers to avoid copying
+// the 16-byte arrays. The difference is negligible in most cases because the
+// compiler usually elides the copies of [Id.Cmp], but this method may be
+// slightly faster in hot loops that compare elements of large slices in place,
+// e.g., ids[i].CmpPtr(&ids[j]). Run the following benchmark tests to compare
+// them on a specific platform:
+//
+//	go test -bench Cmp
+//
+// This method panics if the receiver or the argument is nil.
+func (bs *Id) CmpPtr(other *Id) int {
+	return bytes.Compare(bs[:], other[:])
+}
+
+// Returns -1, 0, or 1 if the object is less than, equal to, or greater than the
+// 16-byte big-endian byte array `b`, respectively.
+//
+// This method compares the object with a raw byte slice, e.g., one scanned from
+// a database, without constructing an Id. It returns an error if the length of
+// `b` is not 16.
+func (bs Id) CmpBytes(b []byte) (int, error) {
+	if len(b) != 16 {
+		return 0, fmt.Errorf(
+			"scru128.Id: invalid length of byte array: %d bytes (expected 16)",
+			len(b))
+	}
+	return bytes.Compare(bs[:], b), nil
+}
+
+// Returns -1, 0, or 1 if `a` is less than, equal to, or greater than `b`,
+// respectively.
+//
+// Id cannot satisfy the cmp.Ordered constraint because it is an array type, so
+// generic ordered containers such as B-trees should be keyed by Id with this
+// function as the comparator, e.g., a container constructed with a `func(a, b
+// K) int` parameter. Alternatively, the canonical string representation, which
+// satisfies cmp.Ordered, sorts in the same order as Id.
+//
+// This function is identical to [CompareStable]; it is provided under the
+// conventional name for comparators, like cmp.Compare and bytes.Compare.
+func Compare(a, b Id) int {
+	return CompareStable(a, b)
+}
+
+// Returns -1, 0, or 1 if `a` is less than, equal to, or greater than `b`,
+// respectively.
+//
+// This function is designed to be passed to slices.SortStableFunc and similar
+// functions that take a three-way comparison function. Two distinct Id values
+// never compare equal, so sorting by this function yields the same order
+// regardless of whether the sort algorithm is stable; the stable variant only
+// matters for elements attached to identical Id values. See also [Compare],
+// which is identical to this function.
+func CompareStable(a, b Id) int {
+	return a.Cmp(b)
+}
+
+// Verifies that `ids` is strictly increasing, returning a
+// [*MonotonicityError] that describes the first violation, or nil if there is
+// none.
+//
+// This function is useful in conformance tests that capture IDs from a
+// generator, possibly of another language port, and assert their ordering.
+// Note that a generator legitimately breaks the order when it resets upon
+// significant clock rollback.
+func CheckMonotonic(ids []Id) error {
+	for i := 1; i < len(ids); i++ {
+		if ids[i-1].Cmp(ids[i]) >= 0 {
+			return &MonotonicityError{Index: i, Prev: ids[i-1], Curr: ids[i]}
+		}
+	}
+	return nil
+}
+
+// Reports whether the generator was likely reset between `prev` and `curr`,
+// i.e., whether `curr` sorts before `prev` even though the caller knows that
+// `curr` was generated after `prev`.
+//
+// [Generator.Generate] and other `OrReset` methods silently reset the generator
+// upon significant clock rollback, breaking the increasing order of IDs. This
+// function helps auditors flag the IDs that may be out of order as a result.
+// It is a heuristic that relies on external knowledge of the generation order,
+// and it is meaningful only for IDs from the same generator; IDs from
+// different generators may legitimately sort in a different order than that of
+// generation.
+func WasLikelyReset(prev, curr Id) bool {
+	return curr.Cmp(prev) < 0
+}
+
+// Represents a violation of the strictly increasing order of IDs reported by
+// [CheckMonotonic].
+type MonotonicityError struct {
+	// The index of the first ID that is not greater than the preceding one.
+	Index int
+
+	// The ID at `Index - 1`.
+	Prev Id
+
+	// The ID at `Index`.
+	Curr Id
+}
+
+// See error
+func (e *MonotonicityError) Error() string {
+	return fmt.Sprintf("scru128.Id: not strictly increasing at index %d: %v after %v",
+		e.Index, e.Curr, e.Prev)
+}
+
+// Returns true if the object and the argument share the same timestamp field
+// value, i.e., if they were generated within the same millisecond.
+func (bs Id) SameMillis(other Id) bool {
+	return bs.Timestamp() == other.Timestamp()
+}
+
+// Returns -1, 0, or 1 if the object is less than, equal to, or greater than the
+// argument, respectively, comparing only the first 12 bytes, i.e., the
+// timestamp, counter_hi, and counter_lo fields, and ignoring the entropy field.
+//
+// This method is useful to diagnose generator issues: a correctly working
+// generator never produces two IDs that share all the 12 leading bytes, so
+// this method should never return 0 for two distinct IDs from the same
+// generator.
+func (bs Id) CmpIgnoringEntropy(other Id) int {
+	return bytes.Compare(bs[:12], other[:12])
+}
+
+// Returns the smallest SCRU128 ID that is greater than the object, i.e., the
+// 128-bit unsigned integer incremented by one.
+//
+// This method helps turn an inclusive upper bound into an exclusive one. The
+// boolean result is false if the object is [Max] and there is no greater ID.
+func (bs Id) Next() (Id, bool) {
+	for i := len(bs) - 1; i >= 0; i-- {
+		bs[i]++
+		if bs[i] != 0 {
+			return bs, true
+		}
+	}
+	return Id{}, false
+}
+
+// Returns the largest SCRU128 ID that is less than the object, i.e., the
+// 128-bit unsigned integer decremented by one.
+//
+// This method is the inverse of [Id.Next]. The boolean result is false if the
+// object is [Nil] and there is no smaller ID.
+func (bs Id) Prev() (Id, bool) {
+	for i := len(bs) - 1; i >= 0; i-- {
+		bs[i]--
+		if bs[i] != 0xff {
+			return bs, true
+		}
+	}
+	return Max, false
+}
+
+// Returns the bitwise complement of the object, so that inverted IDs sort in
+// reverse chronological order, e.g., as newest-first ordering keys in stores
+// that only support ascending scans.
+//
+// This operation is self-inverse: `bs.Inverted().Inverted() == bs`. Note that
+// the inverted value is not a valid SCRU128 ID in a practical sense; its fields
+// do not represent the generation time or counters, and it should only be used
+// as an ordering key and restored with this method before use as an ID.
+func (bs Id) Inverted() Id {
+	for i := range bs {
+		bs[i] = ^bs[i]
+	}
+	return bs
+}
+
 // Translates a big-endian byte sequence into uint64.
 func bytesToUint64(bigEndian []byte) uint64 {
 	var buffer uint64
@@ -92,23 +817,146 @@ func bytesToUint64(bigEndian []byte) uint64 {
 
 // See encoding.BinaryMarshaler
 func (bs Id) MarshalBinary() (data []byte, err error) {
-	return bs[:], nil
+	return bs.AppendBinary(make([]byte, 0, BinaryLen))
+}
+
+// See encoding.BinaryAppender
+//
+// This method appends the 16-byte big-endian byte array to `b` and never
+// returns an error. It does not allocate if `b` has enough capacity.
+func (bs Id) AppendBinary(b []byte) ([]byte, error) {
+	return append(b, bs[:]...), nil
+}
+
+// Copies the 16-byte big-endian byte array into the first 16 bytes of `dst`,
+// e.g., a preallocated region of a memory-mapped file, without allocation.
+//
+// This method returns an error if `dst` is shorter than 16 bytes, in which
+// case `dst` is left untouched.
+func (bs Id) MarshalBinaryTo(dst []byte) error {
+	if len(dst) < 16 {
+		return fmt.Errorf(
+			"scru128.Id: destination too short: %d bytes (expected 16 or more)",
+			len(dst))
+	}
+	copy(dst, bs[:])
+	return nil
+}
+
+// Returns a contiguous byte slice of `16 * len(ids)` bytes that concatenates
+// the 16-byte big-endian byte arrays of `ids`.
+func MarshalBinarySlice(ids []Id) []byte {
+	data := make([]byte, 0, 16*len(ids))
+	for _, e := range ids {
+		data, _ = e.AppendBinary(data)
+	}
+	return data
+}
+
+// Creates a slice of SCRU128 ID objects from a byte slice produced by
+// [MarshalBinarySlice].
+//
+// This function returns an error if the length of `data` is not a multiple of
+// 16.
+func UnmarshalBinarySlice(data []byte) ([]Id, error) {
+	if len(data)%16 != 0 {
+		return nil, fmt.Errorf(
+			"scru128.Id: invalid length of byte array: %d bytes (expected multiple of 16)",
+			len(data))
+	}
+	ids := make([]Id, len(data)/16)
+	for i := range ids {
+		copy(ids[i][:], data[16*i:])
+	}
+	return ids, nil
 }
 
 // See encoding.BinaryUnmarshaler
+//
+// This method accepts either of the following inputs, distinguished by length:
+//
+//   - 16 bytes: the 128-bit unsigned integer in big-endian byte order.
+//   - 25 bytes: the 25-digit textual representation (see [Id.UnmarshalText]).
+//
+// Inputs of any other length, including other textual forms such as 32-digit
+// hexadecimal, are rejected with an error reporting the length.
 func (bs *Id) UnmarshalBinary(data []byte) error {
 	if bs == nil {
 		return fmt.Errorf("scru128.Id: method call on nil receiver")
 	}
-	if len(data) == 16 {
+	if len(data) == BinaryLen {
 		copy(bs[:], data)
 		return nil
-	} else if len(data) == 25 {
+	} else if len(data) == StringLen {
 		return bs.UnmarshalText(data)
 	} else {
 		return fmt.Errorf(
-			"scru128.Id: invalid length of byte array: %d bytes", len(data))
+			"scru128.Id: invalid length of byte array: %d bytes (expected 16 or 25)",
+			len(data))
+	}
+}
+
+// See json.Marshaler
+//
+// This method encodes the object as a JSON string containing the 25-digit
+// canonical representation, which is identical to the result that the
+// encoding/json package produces through [Id.MarshalText]. The explicit
+// implementation ensures consistent results across JSON libraries that do not
+// fully honor encoding.TextMarshaler.
+func (bs Id) MarshalJSON() ([]byte, error) {
+	text, _ := bs.MarshalText()
+	data := make([]byte, 0, len(text)+2)
+	data = append(data, '"')
+	data = append(data, text...)
+	data = append(data, '"')
+	return data, nil
+}
+
+// See json.Unmarshaler
+//
+// This method accepts a JSON string containing the 25-digit textual
+// representation. For interoperability with clients that treat IDs as byte
+// arrays, it also accepts a JSON array of 16 integers in the range of 0 to 255
+// representing the big-endian byte array. A JSON null leaves the object
+// unchanged.
+func (bs *Id) UnmarshalJSON(data []byte) error {
+	if bs == nil {
+		return fmt.Errorf("scru128.Id: method call on nil receiver")
+	}
+	data = bytes.TrimSpace(data)
+	if string(data) == "null" {
+		return nil
+	} else if len(data) > 0 && data[0] == '[' {
+		return bs.unmarshalJSONArray(data)
+	}
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("scru128.Id: could not unmarshal JSON: %w", err)
+	}
+	return bs.UnmarshalText([]byte(text))
+}
+
+// Decodes a JSON array of 16 integers in the range of 0 to 255.
+func (bs *Id) unmarshalJSONArray(data []byte) error {
+	var elems []json.RawMessage
+	if err := json.Unmarshal(data, &elems); err != nil {
+		return fmt.Errorf("scru128.Id: could not unmarshal JSON: %w", err)
+	}
+	if len(elems) != 16 {
+		return fmt.Errorf(
+			"scru128.Id: invalid length of JSON array: %d elements (expected 16)",
+			len(elems))
+	}
+	var dst Id
+	for i, e := range elems {
+		n, err := strconv.ParseUint(string(bytes.TrimSpace(e)), 10, 8)
+		if err != nil {
+			return fmt.Errorf(
+				"scru128.Id: invalid byte value in JSON array: %s at %d", e, i)
+		}
+		dst[i] = byte(n)
 	}
+	return bs.UnmarshalBinary(dst[:])
 }
 
 // Digit characters used in the Base36 notation.
@@ -116,7 +964,27 @@ var digits = []byte("0123456789abcdefghijklmnopqrstuvwxyz")
 
 // See encoding.TextMarshaler
 func (bs Id) MarshalText() (text []byte, err error) {
-	text = make([]byte, 25)
+	return bs.AppendText(make([]byte, 0, StringLen))
+}
+
+// See encoding.TextAppender
+//
+// This method appends the 25-digit canonical string representation to `b` and
+// never returns an error. It does not allocate if `b` has enough capacity.
+func (bs Id) AppendText(b []byte) ([]byte, error) {
+	return bs.AppendString(b), nil
+}
+
+// Appends the 25-digit canonical string representation to `b` and returns the
+// extended buffer.
+//
+// This method is an infallible counterpart of [Id.AppendText] for logging
+// libraries and other code that builds output incrementally without handling
+// errors. It does not allocate if `b` has enough capacity.
+func (bs Id) AppendString(b []byte) []byte {
+	b = append(b, make([]byte, StringLen)...) // zero-filled; no temporary allocation
+	text := b[len(b)-StringLen:]
+
 	minIndex := 99 // any number greater than size of output array
 	for i := -5; i < 16; i += 7 {
 		// implement Base36 using 56-bit words
@@ -142,7 +1010,25 @@ func (bs Id) MarshalText() (text []byte, err error) {
 	for i, e := range text {
 		text[i] = digits[e]
 	}
-	return
+	return b
+}
+
+// Writes the 25-digit canonical string representation to `w`, returning the
+// number of bytes written and any error encountered.
+//
+// This method is a convenient shortcut to write an ID into strings.Builder,
+// bufio.Writer, and other writers without calling [Id.String]. If `w` also
+// implements io.Writer, as the standard writers do, this method writes the
+// bytes encoded in a buffer directly without converting them into an
+// intermediate string. Like [Id.AppendString], this method always writes the
+// canonical lowercase form regardless of [SetStringCase].
+func (bs Id) WriteString(w io.StringWriter) (int, error) {
+	var buffer [StringLen]byte
+	text := bs.AppendString(buffer[:0])
+	if bw, ok := w.(io.Writer); ok {
+		return bw.Write(text)
+	}
+	return w.WriteString(string(text))
 }
 
 // An O(1) map from ASCII code points to Base36 digit values.
@@ -174,27 +1060,27 @@ func (bs *Id) UnmarshalText(text []byte) error {
 	if bs == nil {
 		return fmt.Errorf("scru128.Id: method call on nil receiver")
 	}
-	if len(text) != 25 {
+	if len(text) != StringLen {
 		return newParseError(
-			fmt.Errorf("invalid length: %d bytes (expected 25)", len(text)))
+			fmt.Errorf("%w: %d bytes (expected 25)", ErrInvalidLength, len(text)))
+	}
+
+	if id, ok := parseLowercase(text); ok {
+		*bs = id
+		return nil
 	}
 
-	src := make([]byte, 25)
+	// fall back to general path that handles uppercase and reports errors
+	var src [StringLen]byte
 	for i, e := range text {
 		src[i] = decodeMap[e]
 		if src[i] == 0xff {
-			if e < 0x80 {
-				return newParseError(fmt.Errorf("invalid digit %q at %d", e, i))
-			} else {
-				return newParseError(fmt.Errorf("non-ASCII digit at %d", i))
-			}
+			return newParseError(&InvalidDigitError{Pos: i, Char: e})
 		}
 	}
 
-	for i := range bs {
-		bs[i] = 0
-	}
-
+	// decode into temporary buffer to leave receiver untouched on error
+	var dst Id
 	minIndex := 99 // any number greater than size of output array
 	for i := -5; i < 25; i += 10 {
 		// implement Base36 using 10-digit words
@@ -211,35 +1097,145 @@ func (bs *Id) UnmarshalText(text []byte) error {
 
 		// iterate over output array from right to left while carry != 0 but at
 		// least up to place already filled
-		j := len(bs) - 1
+		j := len(dst) - 1
 		for ; carry > 0 || j > minIndex; j-- {
 			if j < 0 {
-				return newParseError(fmt.Errorf("out of 128-bit value range"))
+				return newParseError(ErrOutOfRange)
 			}
-			carry += uint64(bs[j]) * 3656158440062976 // 36^10
-			bs[j] = byte(carry)
+			carry += uint64(dst[j]) * 3656158440062976 // 36^10
+			dst[j] = byte(carry)
 			carry = carry >> 8
 		}
 		minIndex = j
 	}
+	*bs = dst
 	return nil
 }
 
 // See sql.Scanner
+//
+// This method accepts the following types of `src` in order of precedence:
+//
+//  1. string: parsed as the 25-digit textual representation.
+//  2. []byte: decoded by [Id.UnmarshalBinary].
+//  3. [fmt.Stringer]: the result of String() is parsed as the 25-digit textual
+//     representation. A nil pointer is rejected without calling String().
+//  4. nil (i.e., SQL NULL): leaves the receiver zeroed, i.e., [Nil].
+//
+// Any other type results in an error.
+//
+// Since a NULL value is scanned as [Nil], this method cannot distinguish NULL
+// from the Nil ID stored in a column. To distinguish them, scan into a
+// pointer field (*Id), which database/sql sets to nil upon NULL, or a wrapper
+// such as sql.Null[Id] available in Go 1.22 or later.
 func (bs *Id) Scan(src any) error {
 	if bs == nil {
 		return fmt.Errorf("scru128.Id: method call on nil receiver")
 	}
 	switch src := src.(type) {
+	case nil:
+		*bs = Id{}
+		return nil
 	case string:
 		return bs.UnmarshalText([]byte(src))
 	case []byte:
 		return bs.UnmarshalBinary(src)
+	case fmt.Stringer:
+		if v := reflect.ValueOf(src); v.Kind() == reflect.Pointer && v.IsNil() {
+			return fmt.Errorf("scru128.Id: Scan: nil pointer of type %T", src)
+		}
+		if err := bs.UnmarshalText([]byte(src.String())); err != nil {
+			return fmt.Errorf("scru128.Id: Scan: unsupported type conversion: %w", err)
+		}
+		return nil
 	default:
 		return fmt.Errorf("scru128.Id: Scan: unsupported type conversion")
 	}
 }
 
+// The error value wrapped by the errors returned by the parsing functions when
+// the input has an invalid length.
+var ErrInvalidLength = fmt.Errorf("invalid length")
+
+// The error value wrapped by the errors returned by the parsing functions when
+// the input contains a character that is not a valid digit. The
+// [InvalidDigitError] wrapped as well provides the position of the character.
+var ErrInvalidDigit = fmt.Errorf("invalid digit")
+
+// The error value wrapped by the errors returned by the parsing functions when
+// the input represents a value beyond the 128-bit range.
+var ErrOutOfRange = fmt.Errorf("out of 128-bit value range")
+
+// Represents a parsing error caused by an invalid digit character, providing
+// its position. This error matches [ErrInvalidDigit] through errors.Is.
+type InvalidDigitError struct {
+	// The byte offset of the invalid character in the input.
+	Pos int
+
+	// The invalid byte, which may be the first byte of a non-ASCII character.
+	Char byte
+}
+
+// See error
+func (e *InvalidDigitError) Error() string {
+	if e.Char < 0x80 {
+		return fmt.Sprintf("invalid digit %q at %d", e.Char, e.Pos)
+	} else {
+		return fmt.Sprintf("non-ASCII digit at %d", e.Pos)
+	}
+}
+
+// Reports whether `target` is [ErrInvalidDigit].
+func (e *InvalidDigitError) Is(target error) bool {
+	return target == ErrInvalidDigit
+}
+
+// Decodes the 25 lowercase Base36 digit characters into an ID using 128-bit
+// arithmetic, returning false if `text` contains any other character, including
+// an uppercase letter, or represents a value out of the 128-bit range.
+//
+// This is the fast path of [Id.UnmarshalText] for the common canonical input;
+// the callers must fall back to the general path to handle the other cases.
+func parseLowercase(text []byte) (Id, bool) {
+	const pow10 = 3656158440062976 // 36^10
+
+	// implement Base36 using 5-, 10-, and 10-digit words
+	w0, ok0 := parseLowercaseWord(text[0:5])
+	w1, ok1 := parseLowercaseWord(text[5:15])
+	w2, ok2 := parseLowercaseWord(text[15:25])
+	if !(ok0 && ok1 && ok2) {
+		return Id{}, false
+	}
+
+	// (hi, lo) = (w0 * 36^10 + w1) * 36^10 + w2; the first step never overflows
+	// because the intermediate value is less than 36^15
+	hi, lo := bits.Mul64(w0, pow10)
+	var carry uint64
+	lo, carry = bits.Add64(lo, w1, 0)
+	hi += carry
+
+	hiHi, hiLo := bits.Mul64(hi, pow10)
+	loHi, loLo := bits.Mul64(lo, pow10)
+	lo, carry = bits.Add64(loLo, w2, 0)
+	hi, carry = bits.Add64(hiLo, loHi, carry)
+	return FromUint64Pair(hi, lo), hiHi == 0 && carry == 0
+}
+
+// Decodes up to 12 lowercase Base36 digit characters into an integer, returning
+// false if `text` contains any other character.
+func parseLowercaseWord(text []byte) (word uint64, ok bool) {
+	for _, e := range text {
+		if d := e - '0'; d < 10 {
+			word = word*36 + uint64(d)
+		} else if l := e - 'a'; l < 26 {
+			word = word*36 + uint64(l) + 10
+		} else {
+			return 0, false
+		}
+	}
+	return word, true
+}
+
 // Wraps a raw parsing error to construct a unified error message.
 func newParseError(err error) error {
 	return fmt.Errorf("scru128.Id: could not parse string: %w", err)
@@ -0,0 +1,44 @@
+//go:build go1.21
+
+package scru128
+
+import (
+	"slices"
+	"testing"
+)
+
+// Sorts records by Id stably using CompareStable
+func TestCompareStable(t *testing.T) {
+	type record struct {
+		id    Id
+		index int
+	}
+
+	ids := []Id{
+		FromFields(2, 0, 0, 0),
+		FromFields(0, 0, 0, 1),
+		FromFields(1, 0, 0, 0),
+		FromFields(0, 0, 0, 0),
+	}
+	var records []record
+	for i := 0; i < 3; i++ {
+		for _, e := range ids {
+			records = append(records, record{e, len(records)})
+		}
+	}
+
+	slices.SortStableFunc(records, func(a, b record) int {
+		return CompareStable(a.id, b.id)
+	})
+
+	for i := 1; i < len(records); i++ {
+		prev, curr := records[i-1], records[i]
+		c := CompareStable(prev.id, curr.id)
+		if c > 0 || (c == 0 && prev.index >= curr.index) {
+			t.Fail()
+		}
+		if (c == 0) != (prev.id == curr.id) {
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,15 @@
+//go:build go1.24
+
+package scru128
+
+import (
+	"encoding"
+	"testing"
+)
+
+// Ensures compliance with interfaces introduced in Go 1.24.
+func TestInterfacesGo124(t *testing.T) {
+	var x Id
+	var _ encoding.BinaryAppender = x
+	var _ encoding.TextAppender = x
+}
@@ -1,14 +1,19 @@
 package scru128
 
 import (
+	"bufio"
 	"bytes"
 	"database/sql"
 	"encoding"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"math/big"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 const maxUint48 uint64 = (1 << 48) - 1
@@ -95,6 +100,181 @@ func TestStringValidation(t *testing.T) {
 		if err == nil {
 			t.Fail()
 		}
+
+		x := FromFields(1, 2, 3, 4)
+		if x.UnmarshalText([]byte(e)) == nil || x != FromFields(1, 2, 3, 4) {
+			t.Fail()
+		}
+	}
+}
+
+// Returns errors that identify the kind of parse failure
+func TestParseErrorTypes(t *testing.T) {
+	if _, err := Parse("036z8puq4tsxsigk6o19y164"); !errors.Is(err, ErrInvalidLength) {
+		t.Fail()
+	}
+	if _, err := Parse("zzzzzzzzzzzzzzzzzzzzzzzzz"); !errors.Is(err, ErrOutOfRange) {
+		t.Fail()
+	}
+
+	cases := []struct {
+		input string
+		pos   int
+		char  byte
+	}{
+		{"036z8puq5a7j0t_08p2cdz28v", 14, '_'},
+		{"036z8pu-5a7j0ti08p3ol8ool", 7, '-'},
+		{"039onvvkl\xf0\x9f\xa4\xa3qe7fzr2hdoqu", 9, 0xf0},
+	}
+	for _, e := range cases {
+		_, err := Parse(e.input)
+		var digitErr *InvalidDigitError
+		if !errors.Is(err, ErrInvalidDigit) || !errors.As(err, &digitErr) ||
+			digitErr.Pos != e.pos || digitErr.Char != e.char {
+			t.Fail()
+		}
+		if errors.Is(err, ErrInvalidLength) || errors.Is(err, ErrOutOfRange) {
+			t.Fail()
+		}
+	}
+
+	if _, err := ParseBase62("0000000000000000000000_"); !errors.Is(err, ErrInvalidLength) {
+		t.Fail()
+	}
+	if _, err := ParseBase62("zzzzzzzzzzzzzzzzzzzzzz"); !errors.Is(err, ErrOutOfRange) {
+		t.Fail()
+	}
+	if _, err := ParseBase32Crockford("0000000000000000000000000U"); !errors.Is(err, ErrInvalidDigit) {
+		t.Fail()
+	}
+	if _, err := ParseWithCheck(Nil.String() + "_"); !errors.Is(err, ErrInvalidDigit) {
+		t.Fail()
+	}
+}
+
+// Decodes lowercase, uppercase, and mixed-case input identically
+func TestParseCaseFastPath(t *testing.T) {
+	g := NewGenerator()
+	cases := []Id{Nil, Max, FromFields(maxUint48, 0, 0, 0), FromFields(0, 0, 0, maxUint32)}
+	for i := 0; i < 1_000; i++ {
+		e, _ := g.Generate()
+		cases = append(cases, e)
+	}
+
+	for _, e := range cases {
+		lower := e.String()
+		upper := strings.ToUpper(lower)
+		mixed := upper[:12] + lower[12:]
+		if x, ok := parseLowercase([]byte(lower)); !ok || x != e {
+			t.Fail()
+		}
+		if _, ok := parseLowercase([]byte(mixed)); ok && mixed != lower {
+			t.Fail()
+		}
+		for _, text := range []string{lower, upper, mixed} {
+			if x, err := Parse(text); err != nil || x != e {
+				t.Fail()
+			}
+		}
+	}
+
+	for _, e := range []string{"f5lxx1zz5pnorynqglhzmsp34", "zzzzzzzzzzzzzzzzzzzzzzzzz"} {
+		if _, ok := parseLowercase([]byte(e)); ok {
+			t.Fail()
+		}
+		if _, err := Parse(e); !errors.Is(err, ErrOutOfRange) {
+			t.Fail()
+		}
+	}
+}
+
+// Rejects uppercase input only in strict mode
+func TestParseStrict(t *testing.T) {
+	for _, e := range []string{"036z8puq4tsxsigk6o19y164q", "036Z8PUQ4TSXSIGK6O19Y164Q", "036z8puq4tsxsigk6o19y164Q"} {
+		x, err := Parse(e)
+		if err != nil {
+			t.Fail()
+		}
+		y, err := ParseStrict(e)
+		if e == strings.ToLower(e) {
+			if err != nil || x != y {
+				t.Fail()
+			}
+		} else if !errors.Is(err, ErrInvalidDigit) || y != Nil {
+			t.Fail()
+		}
+	}
+
+	for _, e := range []string{"", "036z8puq4tsxsigk6o19y164", "036z8puq4tsxsigk6o19y164_", "zzzzzzzzzzzzzzzzzzzzzzzzz"} {
+		if _, err := ParseStrict(e); err == nil {
+			t.Fail()
+		}
+	}
+}
+
+// Distinguishes SCRU128 strings from UUIDs and garbage
+func TestLooksLikeScru128(t *testing.T) {
+	g := NewGenerator()
+	for i := 0; i < 1_000; i++ {
+		x, _ := g.Generate()
+		if !LooksLikeScru128(x.String()) || !LooksLikeScru128(strings.ToUpper(x.String())) {
+			t.Fail()
+		}
+	}
+
+	for _, e := range []string{
+		"",
+		"550e8400-e29b-41d4-a716-446655440000",
+		"550e8400e29b41d4a716446655440000",
+		"{550e8400-e29b-41d4-a716-446655440000}",
+		"036z8puq4tsxsigk6o19y164",
+		"036z8puq4tsxsigk6o19y164qq",
+		"036z8puq4tsxsigk6o19y164-",
+		" 036z8puq4tsxsigk6o19y164",
+		"039onvvkl🤣qe7fzr2hdoqu",
+	} {
+		if LooksLikeScru128(e) {
+			t.Fail()
+		}
+	}
+}
+
+// Normalizes valid input to lowercase
+func TestNormalize(t *testing.T) {
+	for _, e := range []string{"036z8puq4tsxsigk6o19y164q", "036Z8PUQ4TSXSIGK6O19Y164Q", "036z8PUQ4tsxsigk6o19Y164q"} {
+		if s, err := Normalize(e); err != nil || s != "036z8puq4tsxsigk6o19y164q" {
+			t.Fail()
+		}
+	}
+	for _, e := range []string{"", "036z8puq4tsxsigk6o19y164", "036z8puq4tsxsigk6o19y164_", "zzzzzzzzzzzzzzzzzzzzzzzzz"} {
+		if s, err := Normalize(e); err == nil || s != "" {
+			t.Fail()
+		}
+	}
+}
+
+// Reads exactly 25 bytes from reader
+func TestParseReader(t *testing.T) {
+	r := strings.NewReader("036z8puq4tsxsigk6o19y164q036z8puq54qny1vq3hcbrkweb036z8pu")
+	for _, e := range []string{"036z8puq4tsxsigk6o19y164q", "036z8puq54qny1vq3hcbrkweb"} {
+		x, err := ParseReader(r)
+		if err != nil || x.String() != e {
+			t.Fail()
+		}
+	}
+
+	_, err := ParseReader(r)
+	if !errors.Is(err, ErrInvalidLength) || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fail()
+	}
+	_, err = ParseReader(r)
+	if !errors.Is(err, ErrInvalidLength) || !errors.Is(err, io.EOF) {
+		t.Fail()
+	}
+
+	_, err = ParseReader(strings.NewReader("036z8puq4tsxsigk6o19y164_"))
+	if !errors.Is(err, ErrInvalidDigit) {
+		t.Fail()
 	}
 }
 
@@ -119,6 +299,9 @@ func TestSymmetricConverters(t *testing.T) {
 		if x, _ := Parse(e.String()); x != e {
 			t.Fail()
 		}
+		if x, err := RoundTripString(e); err != nil || x != e {
+			t.Fail()
+		}
 		if FromFields(
 			e.Timestamp(), e.CounterHi(), e.CounterLo(), e.Entropy(),
 		) != e {
@@ -151,6 +334,62 @@ func TestSymmetricConverters(t *testing.T) {
 	}
 }
 
+// Compares with string representation case-insensitively
+func TestEqualString(t *testing.T) {
+	g := NewGenerator()
+	for i := 0; i < 1_000; i++ {
+		x, _ := g.Generate()
+		s := x.String()
+		if !x.EqualString(s) || !x.EqualString(strings.ToUpper(s)) {
+			t.Fail()
+		}
+
+		y, _ := g.Generate()
+		if x.EqualString(y.String()) || x.EqualString(s[:24]) || x.EqualString(s+"0") {
+			t.Fail()
+		}
+		if x.EqualString(s[:24]+"_") || x.EqualString(s[:24]+"\x00") {
+			t.Fail()
+		}
+	}
+
+	x := FromFields(0x0123_4567_89ab, 0x123456, 0xabcdef, 0xdeadbeef)
+	s := x.String()
+	if allocs := testing.AllocsPerRun(10, func() { x.EqualString(s) }); allocs != 0 {
+		t.Fail()
+	}
+}
+
+// Distinguishes round-tripped ID from tampered string
+func TestRoundTripString(t *testing.T) {
+	x := FromFields(0x0123_4567_89ab, 0x123456, 0xabcdef, 0xdeadbeef)
+	s := x.String()
+	tampered := s[:10] + string(digits[(decodeMap[s[10]]+1)%36]) + s[11:]
+	y, err := Parse(tampered)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if z, err := RoundTripString(x); err != nil || z != x || z == y {
+		t.Fail()
+	}
+	if z, err := RoundTripString(y); err != nil || z != y || z.String() == s {
+		t.Fail()
+	}
+
+	// fails if tampered string decodes into different ID
+	z, err := roundTripString(x, func(Id) string { return tampered })
+	if err == nil || z != y || !strings.Contains(err.Error(), "mismatch") {
+		t.Fail()
+	}
+
+	// fails if corrupted string does not decode
+	if _, err := roundTripString(x, func(Id) string { return s[:10] + "_" + s[11:] }); err == nil ||
+		!errors.Is(err, ErrInvalidDigit) {
+		t.Fail()
+	}
+}
+
 // Supports comparison methods
 func TestComparisonMethods(t *testing.T) {
 	ordered := []Id{
@@ -177,15 +416,288 @@ func TestComparisonMethods(t *testing.T) {
 			t.Fail()
 		}
 
+		if curr.CmpPtr(&prev) <= 0 || prev.CmpPtr(&curr) >= 0 {
+			t.Fail()
+		}
+		if c, err := curr.CmpBytes(prev[:]); c <= 0 || err != nil {
+			t.Fail()
+		}
+		if c, err := prev.CmpBytes(curr[:]); c >= 0 || err != nil {
+			t.Fail()
+		}
+
 		clone := curr
 		if curr != clone || curr.Cmp(clone) != 0 || clone.Cmp(curr) != 0 {
 			t.Fail()
 		}
+		if curr.CmpPtr(&clone) != 0 || clone.CmpPtr(&curr) != 0 {
+			t.Fail()
+		}
+		if c, err := curr.CmpBytes(clone[:]); c != 0 || err != nil {
+			t.Fail()
+		}
+
+		prev = curr
+	}
+}
+
+// Increments 128-bit value by one
+func TestNext(t *testing.T) {
+	cases := []struct {
+		id, expected Id
+	}{
+		{FromUint64Pair(0, 0), FromUint64Pair(0, 1)},
+		{FromUint64Pair(0, 0xff), FromUint64Pair(0, 0x100)},
+		{FromUint64Pair(0, 0xffff), FromUint64Pair(0, 0x1_0000)},
+		{FromUint64Pair(0, 1<<64-1), FromUint64Pair(1, 0)},
+		{FromUint64Pair(0x1234, 0x5678_ffff_ffff), FromUint64Pair(0x1234, 0x5679_0000_0000)},
+		{FromUint64Pair(0xff_ffff, 1<<64-1), FromUint64Pair(0x100_0000, 0)},
+		{FromUint64Pair(1<<64-1, 1<<64-2), Max},
+	}
+
+	for _, e := range cases {
+		next, ok := e.id.Next()
+		if !ok || next != e.expected || next.Cmp(e.id) <= 0 {
+			t.Fail()
+		}
+	}
+
+	if next, ok := Max.Next(); ok || next != (Id{}) {
+		t.Fail()
+	}
+	if Max != FromFields(maxUint48, maxUint24, maxUint24, maxUint32) {
+		t.Fail()
+	}
+}
+
+// Decrements 128-bit value by one
+func TestPrev(t *testing.T) {
+	cases := []struct {
+		id, expected Id
+	}{
+		{FromUint64Pair(0, 1), FromUint64Pair(0, 0)},
+		{FromUint64Pair(0, 0x100), FromUint64Pair(0, 0xff)},
+		{FromUint64Pair(0, 0x1_0000), FromUint64Pair(0, 0xffff)},
+		{FromUint64Pair(1, 0), FromUint64Pair(0, 1<<64-1)},
+		{FromUint64Pair(0x1234, 0x5679_0000_0000), FromUint64Pair(0x1234, 0x5678_ffff_ffff)},
+		{FromUint64Pair(0x100_0000, 0), FromUint64Pair(0xff_ffff, 1<<64-1)},
+		{Max, FromUint64Pair(1<<64-1, 1<<64-2)},
+	}
+
+	for _, e := range cases {
+		prev, ok := e.id.Prev()
+		if !ok || prev != e.expected || prev.Cmp(e.id) >= 0 {
+			t.Fail()
+		}
+		if next, _ := prev.Next(); next != e.id {
+			t.Fail()
+		}
+	}
+
+	if prev, ok := Nil.Prev(); ok || prev != Max {
+		t.Fail()
+	}
+	if Nil != FromFields(0, 0, 0, 0) {
+		t.Fail()
+	}
+}
+
+// Replaces only entropy field
+func TestWithEntropy(t *testing.T) {
+	g := NewGenerator()
+	for i := 0; i < 1_000; i++ {
+		x, _ := g.Generate()
+		y := x.WithEntropy(0xdeadbeef)
+		if y != FromFields(x.Timestamp(), x.CounterHi(), x.CounterLo(), 0xdeadbeef) ||
+			!bytes.Equal(x[:12], y[:12]) || y.Entropy() != 0xdeadbeef {
+			t.Fail()
+		}
+		if y.WithEntropy(x.Entropy()) != x {
+			t.Fail()
+		}
+
+		// sorts by entropy among IDs sharing other fields
+		prev := x.WithEntropy(0)
+		for _, e := range []uint32{1, 0xff, 0x100, 0xffff_fffe, maxUint32} {
+			curr := x.WithEntropy(e)
+			if prev.Cmp(curr) >= 0 || curr.Timestamp() != x.Timestamp() {
+				t.Fail()
+			}
+			prev = curr
+		}
+	}
+}
+
+// Validates every field value including entropy
+func TestFromFieldsStrict(t *testing.T) {
+	cases := []struct {
+		timestamp, counterHi, counterLo, entropy uint64
+	}{
+		{0, 0, 0, 0},
+		{0x0123_4567_89ab, 0x123456, 0xabcdef, 0xdeadbeef},
+		{uint64(maxUint48), uint64(maxUint24), uint64(maxUint24), MaxEntropy},
+	}
+	for _, e := range cases {
+		x, err := FromFieldsStrict(e.timestamp, e.counterHi, e.counterLo, e.entropy)
+		if err != nil || x != FromFields(e.timestamp, uint32(e.counterHi),
+			uint32(e.counterLo), uint32(e.entropy)) {
+			t.Fail()
+		}
+	}
+	if x, _ := FromFieldsStrict(0, 0, 0, MaxEntropy); x.Entropy() != maxUint32 {
+		t.Fail()
+	}
+
+	cases = []struct {
+		timestamp, counterHi, counterLo, entropy uint64
+	}{
+		{uint64(maxUint48) + 1, 0, 0, 0},
+		{0, uint64(maxUint24) + 1, 0, 0},
+		{0, 0, uint64(maxUint24) + 1, 0},
+		{0, 0, 0, MaxEntropy + 1},
+		{1 << 63, 1 << 63, 1 << 63, 1 << 63},
+	}
+	for _, e := range cases {
+		x, err := FromFieldsStrict(e.timestamp, e.counterHi, e.counterLo, e.entropy)
+		if err == nil || x != Nil {
+			t.Fail()
+		}
+	}
+}
+
+// Creates ID from time and field values
+func TestFromFieldsAt(t *testing.T) {
+	for _, e := range []time.Time{
+		time.UnixMilli(0),
+		time.Date(2023, 7, 22, 4, 26, 40, 123_456_789, time.UTC),
+		time.Date(2023, 7, 22, 13, 26, 40, 999_999_999, time.FixedZone("JST", 9*3600)),
+		time.UnixMilli(int64(maxTimestamp)).Add(999_999),
+		time.Now(),
+	} {
+		x, err := FromFieldsAt(e, 0x123456, 0xabcdef, 0xdeadbeef)
+		if err != nil || x != FromFields(uint64(e.UnixMilli()), 0x123456, 0xabcdef, 0xdeadbeef) {
+			t.Fail()
+		}
+		if !x.Time().Equal(e.Truncate(time.Millisecond)) {
+			t.Fail()
+		}
+	}
+
+	for _, e := range []struct {
+		t                    time.Time
+		counterHi, counterLo uint32
+	}{
+		{time.UnixMilli(-1), 0, 0},
+		{time.Unix(0, -1), 0, 0},
+		{time.UnixMilli(int64(maxTimestamp) + 1), 0, 0},
+		{time.Now(), maxCounterHi + 1, 0},
+		{time.Now(), 0, maxCounterLo + 1},
+	} {
+		if x, err := FromFieldsAt(e.t, e.counterHi, e.counterLo, 0); err == nil || x != Nil {
+			t.Fail()
+		}
+	}
+}
+
+// Reverses order by bitwise complement
+func TestInverted(t *testing.T) {
+	if Nil.Inverted() != Max || Max.Inverted() != Nil {
+		t.Fail()
+	}
+	if FromUint64Pair(0x0123_4567_89ab_cdef, 0xfedc_ba98_7654_3210).Inverted() !=
+		FromUint64Pair(0xfedc_ba98_7654_3210, 0x0123_4567_89ab_cdef) {
+		t.Fail()
+	}
 
+	g := NewGenerator()
+	prev, _ := g.Generate()
+	for i := 0; i < 10_000; i++ {
+		curr, _ := g.Generate()
+		if curr.Inverted().Inverted() != curr {
+			t.Fail()
+		}
+		if prev.Cmp(curr) != -prev.Inverted().Cmp(curr.Inverted()) {
+			t.Fail()
+		}
+		if prev.Inverted().Cmp(curr.Inverted()) <= 0 {
+			t.Fail()
+		}
 		prev = curr
 	}
 }
 
+// Reports first violation of strictly increasing order
+func TestCheckMonotonic(t *testing.T) {
+	if CheckMonotonic(nil) != nil || CheckMonotonic([]Id{Max}) != nil {
+		t.Fail()
+	}
+
+	g := NewGenerator()
+	ids := make([]Id, 1_000)
+	for i := range ids {
+		ids[i], _ = g.Generate()
+	}
+	if CheckMonotonic(ids) != nil {
+		t.Fail()
+	}
+
+	for _, e := range []struct {
+		index int
+		value Id
+	}{{500, ids[100]}, {500, ids[499]}, {999, Nil}, {1, ids[0]}} {
+		injected := append([]Id(nil), ids...)
+		injected[e.index] = e.value
+		err := CheckMonotonic(injected)
+		var merr *MonotonicityError
+		if !errors.As(err, &merr) || merr.Index != e.index ||
+			merr.Prev != ids[e.index-1] || merr.Curr != e.value {
+			t.Fail()
+		}
+		if !strings.Contains(err.Error(), e.value.String()) {
+			t.Fail()
+		}
+	}
+
+	// reports violation at element after injected large value
+	injected := append([]Id(nil), ids...)
+	injected[0] = Max
+	if err := CheckMonotonic(injected); err.(*MonotonicityError).Index != 1 {
+		t.Fail()
+	}
+}
+
+// Rejects byte slice of invalid length in comparison
+func TestCmpBytesValidation(t *testing.T) {
+	x := FromFields(1, 2, 3, 4)
+	text, _ := x.MarshalText()
+	for _, e := range [][]byte{nil, {}, x[:15], append(x[:], 0), text} {
+		if _, err := x.CmpBytes(e); err == nil {
+			t.Fail()
+		}
+	}
+}
+
+// Tells whether two IDs share the same timestamp
+func TestSameMillis(t *testing.T) {
+	cases := []struct {
+		a, b     Id
+		expected bool
+	}{
+		{FromFields(0, 0, 0, 0), FromFields(0, 0, 0, 0), true},
+		{FromFields(0, 0, 0, 0), FromFields(0, maxUint24, maxUint24, maxUint32), true},
+		{FromFields(0, 0, 0, 0), FromFields(1, 0, 0, 0), false},
+		{FromFields(maxUint48, 0, 0, 0), FromFields(maxUint48, 1, 2, 3), true},
+		{FromFields(maxUint48, 0, 0, 0), FromFields(maxUint48-1, maxUint24, maxUint24, maxUint32), false},
+		{FromFields(maxUint48, maxUint24, maxUint24, maxUint32), FromFields(0, maxUint24, maxUint24, maxUint32), false},
+	}
+
+	for _, e := range cases {
+		if e.a.SameMillis(e.b) != e.expected || e.b.SameMillis(e.a) != e.expected {
+			t.Fail()
+		}
+	}
+}
+
 // Serializes and deserializes an object using the canonical string
 // representation
 func TestSerializedForm(t *testing.T) {
@@ -203,13 +715,1049 @@ func TestSerializedForm(t *testing.T) {
 	}
 }
 
-// Ensures compliance with interfaces.
-func TestInterfaces(t *testing.T) {
-	var x Id
-	var _ fmt.Stringer = x
-	var _ encoding.TextMarshaler = x
-	var _ encoding.TextUnmarshaler = &x
-	var _ encoding.BinaryMarshaler = x
-	var _ encoding.BinaryUnmarshaler = &x
-	var _ sql.Scanner = &x
+// Has representations of constant lengths
+func TestLengthConstants(t *testing.T) {
+	g := NewGenerator()
+	for _, e := range []Id{Nil, Max, FromFields(1, 2, 3, 4)} {
+		x, _ := g.Generate()
+		for _, id := range []Id{e, x} {
+			text, _ := id.MarshalText()
+			bin, _ := id.MarshalBinary()
+			if len(id.String()) != StringLen || len(text) != StringLen ||
+				len(bin) != BinaryLen || len(id) != BinaryLen {
+				t.Fail()
+			}
+		}
+	}
+}
+
+// Formats with each verb
+func TestFormatter(t *testing.T) {
+	x := FromFields(1, 2, 3, 4)
+	cases := []struct {
+		format   string
+		expected string
+	}{
+		{"%v", "0000000005gv2spp2rq9laqys"},
+		{"%s", "0000000005gv2spp2rq9laqys"},
+		{"%+v", "{timestamp:1 counterHi:2 counterLo:3 entropy:4}"},
+		{"%#v", "scru128.Id{0x0, 0x0, 0x0, 0x0, 0x0, 0x1, 0x0, 0x0, 0x2, 0x0, 0x0, 0x3, 0x0, 0x0, 0x0, 0x4}"},
+		{"%q", `"0000000005gv2spp2rq9laqys"`},
+		{"%27v", "  0000000005gv2spp2rq9laqys"},
+		{"%-27s|", "0000000005gv2spp2rq9laqys  |"},
+		{"%x", "30303030303030303035677632737070327271396c61717973"},
+		{"%d", "[0 0 0 0 0 1 0 0 2 0 0 3 0 0 0 4]"},
+		{"%3d", "[  0   0   0   0   0   1   0   0   2   0   0   3   0   0   0   4]"},
+		{"%08b", "[00000000 00000000 00000000 00000000 00000000 00000001 00000000 00000000 00000010 00000000 00000000 00000011 00000000 00000000 00000000 00000100]"},
+	}
+
+	for _, e := range cases {
+		if s := fmt.Sprintf(e.format, x); s != e.expected {
+			t.Errorf("%s: got %s", e.format, s)
+		}
+	}
+
+	if s := fmt.Sprintf("%v", []Id{x, Nil}); s != "[0000000005gv2spp2rq9laqys 0000000000000000000000000]" {
+		t.Fail()
+	}
+}
+
+// A fmt.Stringer implementation that is neither string nor []byte
+type testStringer struct{ s string }
+
+func (x testStringer) String() string { return x.s }
+
+// Compares IDs ignoring entropy
+func TestCmpIgnoringEntropy(t *testing.T) {
+	x := FromFields(0x0123_4567_89ab, 0x123456, 0xabcdef, 0)
+	cases := []struct {
+		other    Id
+		expected int
+	}{
+		{FromFields(0x0123_4567_89ab, 0x123456, 0xabcdef, 0), 0},
+		{FromFields(0x0123_4567_89ab, 0x123456, 0xabcdef, maxUint32), 0},
+		{FromFields(0x0123_4567_89ab, 0x123456, 0xabcdee, maxUint32), 1},
+		{FromFields(0x0123_4567_89ab, 0x123456, 0xabcdf0, 0), -1},
+		{FromFields(0x0123_4567_89ab, 0x123457, 0, 0), -1},
+		{FromFields(0x0123_4567_89aa, maxUint24, maxUint24, maxUint32), 1},
+	}
+
+	for _, e := range cases {
+		if x.CmpIgnoringEntropy(e.other) != e.expected ||
+			e.other.CmpIgnoringEntropy(x) != -e.expected {
+			t.Fail()
+		}
+	}
+}
+
+// Tests timestamp against half-open time range
+func TestInTimeRange(t *testing.T) {
+	ts := time.UnixMilli(1690000000123)
+	x := FromFields(uint64(ts.UnixMilli()), maxUint24, maxUint24, maxUint32)
+	ms := time.Millisecond
+	cases := []struct {
+		start, end time.Time
+		expected   bool
+	}{
+		{ts, ts.Add(ms), true},
+		{ts.Add(-ms), ts, false},
+		{ts.Add(ms), ts.Add(2 * ms), false},
+		{ts.Add(-time.Hour), ts.Add(time.Hour), true},
+		{ts.Add(time.Microsecond), ts.Add(ms), false},
+		{ts, ts, false},
+		{time.Time{}, ts.Add(ms), true},
+		{time.Time{}, ts, false},
+		{ts, time.Time{}, true},
+		{ts.Add(ms), time.Time{}, false},
+		{time.Time{}, time.Time{}, true},
+		{ts.In(time.FixedZone("X", 3600)), ts.Add(ms).In(time.FixedZone("Y", -3600)), true},
+	}
+
+	for _, e := range cases {
+		if x.InTimeRange(e.start, e.end) != e.expected {
+			t.Errorf("[%v, %v): got %v", e.start, e.end, !e.expected)
+		}
+	}
+}
+
+// Returns time elapsed since timestamp
+func TestAge(t *testing.T) {
+	x, _ := NewGenerator().Generate()
+	if age := x.Age(); age < 0 || age > time.Second {
+		t.Fail()
+	}
+
+	ts := time.Now().Add(-time.Hour)
+	y := FromFields(uint64(ts.UnixMilli()), 0, 0, 0)
+	if age := y.Age(); age < time.Hour || age > time.Hour+time.Second {
+		t.Fail()
+	}
+
+	z := FromFields(uint64(time.Now().Add(time.Hour).UnixMilli()), 0, 0, 0)
+	if age := z.Age(); age > -time.Hour+time.Second || age < -time.Hour {
+		t.Fail()
+	}
+}
+
+// Subtracts timestamps as duration
+func TestSub(t *testing.T) {
+	var ts uint64 = 0x0123_4567_89ab
+	x := FromFields(ts, 0, 0, 0)
+	cases := []struct {
+		other    Id
+		expected time.Duration
+	}{
+		{FromFields(ts, maxCounterHi, maxCounterLo, maxUint32), 0},
+		{FromFields(ts-1, 0, 0, 0), time.Millisecond},
+		{FromFields(ts+1, 0, 0, 0), -time.Millisecond},
+		{FromFields(ts-3_600_000, 0x123456, 0, 0), time.Hour},
+		{FromFields(ts+86_400_123, 0, 0, 0), -24*time.Hour - 123*time.Millisecond},
+	}
+	for _, e := range cases {
+		if x.Sub(e.other) != e.expected || e.other.Sub(x) != -e.expected {
+			t.Fail()
+		}
+	}
+
+	// saturates beyond range of time.Duration
+	if Max.Sub(Nil) != time.Duration(1<<63-1) || Nil.Sub(Max) != time.Duration(-1<<63) {
+		t.Fail()
+	}
+}
+
+// Floors timestamp to bucket aligned to Unix epoch
+func TestBucket(t *testing.T) {
+	x := FromFields(1690000000123, 0, 0, 0) // 2023-07-22T04:26:40.123Z
+	cases := []struct {
+		d        time.Duration
+		expected int64
+	}{
+		{time.Millisecond, 1690000000123},
+		{time.Second, 1690000000000},
+		{time.Minute, 1689999960000},
+		{15 * time.Minute, 1689999300000},
+		{time.Hour, 1689998400000},
+		{24 * time.Hour, 1689984000000},
+		{7 * 24 * time.Hour, 1689811200000},
+	}
+
+	for _, e := range cases {
+		if got := x.Bucket(e.d); !got.Equal(time.UnixMilli(e.expected)) ||
+			got.Location() != time.UTC {
+			t.Errorf("%v: got %v", e.d, got)
+		}
+	}
+
+	if Max.Bucket(time.Hour) != time.UnixMilli(int64(maxUint48)).Truncate(time.Hour).UTC() {
+		t.Fail()
+	}
+	if Max.Bucket(time.Microsecond) != Max.Time() {
+		t.Fail()
+	}
+}
+
+// Returns sortable 10-digit prefix
+func TestTimeKey(t *testing.T) {
+	g := NewGenerator()
+	prev, _ := g.Generate()
+	for i := 0; i < 10_000; i++ {
+		curr, _ := g.Generate()
+		if curr.TimeKey() != curr.String()[:10] || curr.TimeKey() < prev.TimeKey() {
+			t.Fail()
+		}
+		prev = curr
+	}
+
+	x := FromFields(0x0123_4567_89ab, 0, 0, 0)
+	y := FromFields(0x0123_4567_89ab, maxUint24, maxUint24, maxUint32)
+	z := FromFields(0x0123_4567_89ac, 0, 0, 0)
+	if x.TimeKey() >= z.TimeKey() || y.TimeKey() > z.TimeKey() {
+		t.Fail()
+	}
+}
+
+// Masks entropy digits while keeping order
+func TestRedactedString(t *testing.T) {
+	g := NewGenerator()
+	prev, _ := g.Generate()
+	for i := 0; i < 1_000; i++ {
+		curr, _ := g.Generate()
+		a := FromFields(curr.Timestamp(), curr.CounterHi(), curr.CounterLo(), 0)
+		b := FromFields(curr.Timestamp(), curr.CounterHi(), curr.CounterLo(), maxUint32)
+		r := curr.RedactedString()
+		if a.RedactedString() != r || b.RedactedString() != r {
+			t.Fail()
+		}
+		if len(r) != 25 || r[18:] != "*******" || r[:18] != a.String()[:18] {
+			t.Fail()
+		}
+		if prev.RedactedString() > r {
+			t.Fail()
+		}
+		prev = curr
+	}
+}
+
+// Builds smallest and largest IDs for time
+func TestMinMaxForTime(t *testing.T) {
+	g := NewGenerator()
+	for i := 0; i < 1_000; i++ {
+		x, _ := g.Generate()
+		ts := x.Time().Add(time.Duration(i%1_000) * time.Microsecond)
+		lo, hi := MinForTime(ts), MaxForTime(ts)
+		if lo.Cmp(x) > 0 || hi.Cmp(x) < 0 ||
+			lo.Timestamp() != x.Timestamp() || hi.Timestamp() != x.Timestamp() {
+			t.Fail()
+		}
+		if next := MinForTime(ts.Add(time.Millisecond)); next.Cmp(hi) <= 0 {
+			t.Fail()
+		}
+	}
+
+	if MinForTime(time.UnixMilli(0)) != FromFields(0, 0, 0, 0) ||
+		MaxForTime(time.UnixMilli(int64(maxUint48))) !=
+			FromFields(maxUint48, maxUint24, maxUint24, maxUint32) {
+		t.Fail()
+	}
+
+	for _, e := range []time.Time{
+		time.UnixMilli(-1),
+		time.UnixMilli(int64(maxUint48) + 1),
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fail()
+				}
+			}()
+			MinForTime(e)
+		}()
+	}
+}
+
+// Derives stable IDs from namespace and name
+func TestFromName(t *testing.T) {
+	ns, _ := Parse("036z8puq4tsxsigk6o19y164q")
+	cases := []struct {
+		namespace Id
+		name      string
+		expected  string
+	}{
+		{Nil, "example.com", "amga6o5sy6o1p91erz0lgvz9s"},
+		{ns, "user:42", "c0j6tp3opmqk8xqi5y5jgq7tb"},
+	}
+
+	for _, e := range cases {
+		for i := 0; i < 3; i++ {
+			if x := FromName(e.namespace, []byte(e.name)); x.String() != e.expected {
+				t.Errorf("%q: got %v", e.name, x)
+			}
+		}
+	}
+
+	if FromName(ns, []byte("user:42")) == FromName(ns, []byte("user:43")) ||
+		FromName(ns, []byte("user:42")) == FromName(Nil, []byte("user:42")) {
+		t.Fail()
+	}
+}
+
+// Divides time range into contiguous ID ranges
+func TestTimeRangeShards(t *testing.T) {
+	start := time.UnixMilli(1690000000000)
+	cases := []struct {
+		end      time.Time
+		shards   int
+		expected int
+	}{
+		{start.Add(time.Hour), 1, 1},
+		{start.Add(time.Hour), 7, 7},
+		{start.Add(time.Hour), 1_000, 1_000},
+		{start.Add(5 * time.Millisecond), 8, 5},
+		{start.Add(time.Millisecond), 3, 1},
+		{start, 3, 0},
+		{start.Add(-time.Hour), 3, 0},
+	}
+
+	for _, e := range cases {
+		shards := TimeRangeShards(start, e.end, e.shards)
+		if len(shards) != e.expected {
+			t.Errorf("%v: got %d shards", e, len(shards))
+			continue
+		}
+		if len(shards) == 0 {
+			continue
+		}
+
+		// covers full range
+		if shards[0].Lo != MinForTime(start) ||
+			shards[len(shards)-1].Hi != MaxForTime(e.end.Add(-time.Millisecond)) {
+			t.Fail()
+		}
+
+		// contiguous without overlap
+		for i, r := range shards {
+			if r.Lo.Cmp(r.Hi) >= 0 {
+				t.Fail()
+			}
+			if i > 0 {
+				if next, _ := shards[i-1].Hi.Next(); next != r.Lo {
+					t.Fail()
+				}
+			}
+		}
+
+		// nearly equal time spans
+		span := e.end.Sub(start).Milliseconds() / int64(len(shards))
+		for _, r := range shards {
+			if d := int64(r.Hi.Timestamp()-r.Lo.Timestamp()) + 1; d != span && d != span+1 {
+				t.Fail()
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fail()
+		}
+	}()
+	TimeRangeShards(start, start.Add(time.Hour), 0)
+}
+
+// Creates ID from timestamp with random entropy
+func TestFromTimestamp(t *testing.T) {
+	for _, ts := range []uint64{0, 1, 0x0123_4567_89ab, maxUint48} {
+		set := make(map[uint32]struct{})
+		for i := 0; i < 100; i++ {
+			x, err := FromTimestamp(ts)
+			if err != nil || x.Timestamp() != ts ||
+				x.CounterHi() != 0 || x.CounterLo() != 0 {
+				t.Fail()
+			}
+			set[x.Entropy()] = struct{}{}
+		}
+		if len(set) < 95 {
+			t.Fail()
+		}
+	}
+
+	if _, err := FromTimestamp(maxUint48 + 1); err == nil {
+		t.Fail()
+	}
+}
+
+// Converts from/to pair of uint64 values
+func TestUint64Pair(t *testing.T) {
+	cases := []struct {
+		id Id
+		hi uint64
+		lo uint64
+	}{
+		{FromFields(0, 0, 0, 0), 0, 0},
+		{FromFields(maxUint48, 0, 0, 0), 0xffff_ffff_ffff_0000, 0},
+		{FromFields(0, maxUint24, 0, 0), 0xffff, 0xff00_0000_0000_0000},
+		{FromFields(0, 0, maxUint24, 0), 0, 0x00ff_ffff_0000_0000},
+		{FromFields(0, 0, 0, maxUint32), 0, 0xffff_ffff},
+		{
+			FromFields(0x0123_4567_89ab, 0xcdef01, 0x234567, 0x89abcdef),
+			0x0123_4567_89ab_cdef,
+			0x0123_4567_89ab_cdef,
+		},
+	}
+
+	for _, e := range cases {
+		if hi, lo := e.id.Uint64Pair(); hi != e.hi || lo != e.lo {
+			t.Fail()
+		}
+		if FromUint64Pair(e.hi, e.lo) != e.id {
+			t.Fail()
+		}
+	}
+
+	g := NewGenerator()
+	for i := 0; i < 1_000; i++ {
+		e, _ := g.Generate()
+		hi, lo := e.Uint64Pair()
+		if FromUint64Pair(hi, lo) != e ||
+			hi>>16 != e.Timestamp() ||
+			hi&0xffff != uint64(e.CounterHi()>>8) {
+			t.Fail()
+		}
+	}
+}
+
+// Converts from/to pair of int64 values
+func TestSQLPair(t *testing.T) {
+	cases := []struct {
+		id Id
+		hi int64
+		lo int64
+	}{
+		{FromFields(0, 0, 0, 0), 0, 0},
+		{FromFields(0, 0, 0, maxUint32), 0, 0xffff_ffff},
+		{FromFields(0, maxUint24, 0, 0), 0xffff, -0x0100_0000_0000_0000},
+		{FromFields(maxUint48, 0, 0, 0), -0x1_0000, 0},
+		{Max, -1, -1},
+		{FromUint64Pair(1<<63, 1<<63), -1 << 63, -1 << 63},
+		{FromUint64Pair(1<<63-1, 1<<63-1), 1<<63 - 1, 1<<63 - 1},
+	}
+
+	for _, e := range cases {
+		if hi, lo := e.id.SQLPair(); hi != e.hi || lo != e.lo {
+			t.Fail()
+		}
+		if FromSQLPair(e.hi, e.lo) != e.id {
+			t.Fail()
+		}
+	}
+
+	g := NewGenerator()
+	for i := 0; i < 1_000; i++ {
+		e, _ := g.Generate()
+		if FromSQLPair(e.SQLPair()) != e {
+			t.Fail()
+		}
+	}
+}
+
+// Appends textual representation without allocation
+func TestAppendText(t *testing.T) {
+	g := NewGenerator()
+	buffer := make([]byte, 0, 64)
+	for i := 0; i < 1_000; i++ {
+		e, _ := g.Generate()
+		buffer = append(buffer[:0], "prefix:"...)
+		buffer, _ = e.AppendText(buffer)
+		if string(buffer) != "prefix:"+e.String() {
+			t.Fail()
+		}
+
+		allocs := testing.AllocsPerRun(10, func() {
+			buffer, _ = e.AppendText(buffer[:7])
+		})
+		if allocs != 0 || string(buffer) != "prefix:"+e.String() {
+			t.Fail()
+		}
+	}
+
+	// overwrites stale bytes beyond length
+	buffer = []byte("zzzzzzzzzzzzzzzzzzzzzzzzzzzzzz")
+	buffer, _ = Nil.AppendText(buffer[:1])
+	if string(buffer) != "z0000000000000000000000000" {
+		t.Fail()
+	}
+}
+
+// Copies binary representation into fixed buffer
+func TestMarshalBinaryTo(t *testing.T) {
+	x := FromFields(0x0123_4567_89ab, 0x123456, 0xabcdef, 0xdeadbeef)
+	bin, _ := x.MarshalBinary()
+	buffer := bytes.Repeat([]byte{0xff}, 20)
+	if err := x.MarshalBinaryTo(buffer[2:]); err != nil ||
+		!bytes.Equal(buffer[2:18], bin) ||
+		!bytes.Equal(buffer[:2], []byte{0xff, 0xff}) ||
+		!bytes.Equal(buffer[18:], []byte{0xff, 0xff}) {
+		t.Fail()
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		x.MarshalBinaryTo(buffer)
+	})
+	if allocs != 0 {
+		t.Fail()
+	}
+
+	short := make([]byte, 15)
+	if x.MarshalBinaryTo(short) == nil || !bytes.Equal(short, make([]byte, 15)) {
+		t.Fail()
+	}
+	if x.MarshalBinaryTo(nil) == nil {
+		t.Fail()
+	}
+}
+
+// Appends string representation equivalent to AppendText
+func TestAppendString(t *testing.T) {
+	g := NewGenerator()
+	buffer := make([]byte, 0, 64)
+	for i := 0; i < 1_000; i++ {
+		e, _ := g.Generate()
+		buffer = e.AppendString(append(buffer[:0], "id="...))
+		text, _ := e.AppendText([]byte("id="))
+		if string(buffer) != "id="+e.String() || !bytes.Equal(buffer, text) {
+			t.Fail()
+		}
+	}
+}
+
+// Writes string representation to string writers
+func TestWriteString(t *testing.T) {
+	g := NewGenerator()
+	var sb strings.Builder
+	var bb bytes.Buffer
+	bw := bufio.NewWriter(&bb)
+	var sw stringWriterOnly
+	var expected string
+	for i := 0; i < 1_000; i++ {
+		e, _ := g.Generate()
+		expected += e.String()
+		for _, w := range []io.StringWriter{&sb, bw, &sw} {
+			if n, err := e.WriteString(w); n != 25 || err != nil {
+				t.Fail()
+			}
+		}
+	}
+	bw.Flush()
+	if sb.String() != expected || bb.String() != expected || sw.String() != expected {
+		t.Fail()
+	}
+}
+
+// Implements io.StringWriter but not io.Writer
+type stringWriterOnly struct {
+	sb strings.Builder
+}
+
+func (w *stringWriterOnly) WriteString(s string) (int, error) {
+	return w.sb.WriteString(s)
+}
+
+func (w *stringWriterOnly) String() string {
+	return w.sb.String()
+}
+
+// Appends binary representation without allocation
+func TestAppendBinary(t *testing.T) {
+	ids := make([]Id, 1_000)
+	g := NewGenerator()
+	for i := range ids {
+		ids[i], _ = g.Generate()
+	}
+
+	buffer := make([]byte, 0, 16*len(ids))
+	allocs := testing.AllocsPerRun(10, func() {
+		buffer = buffer[:0]
+		for _, e := range ids {
+			buffer, _ = e.AppendBinary(buffer)
+		}
+	})
+	if allocs != 0 || len(buffer) != 16*len(ids) {
+		t.Fail()
+	}
+	for i, e := range ids {
+		marshaled, _ := e.MarshalBinary()
+		if !bytes.Equal(buffer[16*i:16*(i+1)], e[:]) ||
+			!bytes.Equal(marshaled, e[:]) {
+			t.Fail()
+		}
+	}
+
+	prefix := []byte("prefix")
+	appended, err := ids[0].AppendBinary(prefix)
+	if err != nil || string(appended[:6]) != "prefix" ||
+		!bytes.Equal(appended[6:], ids[0][:]) {
+		t.Fail()
+	}
+}
+
+// Marshals and unmarshals slice of IDs as contiguous byte slice
+func TestBinarySlice(t *testing.T) {
+	g := NewGenerator()
+	for _, n := range []int{0, 1, 2, 1_000} {
+		ids := make([]Id, n)
+		for i := range ids {
+			ids[i], _ = g.Generate()
+		}
+
+		data := MarshalBinarySlice(ids)
+		if len(data) != 16*n {
+			t.Fail()
+		}
+		unmarshaled, err := UnmarshalBinarySlice(data)
+		if err != nil || unmarshaled == nil || len(unmarshaled) != n {
+			t.Fail()
+		}
+		for i, e := range ids {
+			if !bytes.Equal(data[16*i:16*(i+1)], e[:]) || unmarshaled[i] != e {
+				t.Fail()
+			}
+		}
+	}
+
+	if ids, err := UnmarshalBinarySlice(nil); err != nil || len(ids) != 0 {
+		t.Fail()
+	}
+	for _, n := range []int{1, 15, 17, 25, 33} {
+		if _, err := UnmarshalBinarySlice(make([]byte, n)); err == nil {
+			t.Fail()
+		}
+	}
+}
+
+// Scans string, []byte, and fmt.Stringer sources and rejects others
+func TestScan(t *testing.T) {
+	e := FromFields(0x0123_4567_89ab, 0x123456, 0xabcdef, 0xdeadbeef)
+	binary, _ := e.MarshalBinary()
+	for _, src := range []any{
+		e.String(),
+		strings.ToUpper(e.String()),
+		binary,
+		[]byte(e.String()),
+		testStringer{e.String()},
+		e,
+	} {
+		var x Id
+		if err := x.Scan(src); err != nil || x != e {
+			t.Fail()
+		}
+	}
+
+	for _, src := range []any{
+		"",
+		[]byte{1, 2, 3},
+		testStringer{"invalid"},
+		42,
+		3.14,
+	} {
+		var x Id
+		if x.Scan(src) == nil {
+			t.Fail()
+		}
+	}
+
+	// rejects typed nil and non-ID stringers without panicking
+	for _, src := range []any{(*Id)(nil), time.Unix(0, 0)} {
+		x := e
+		err := x.Scan(src)
+		if err == nil || x != e {
+			t.Fail()
+		}
+		if _, ok := src.(time.Time); ok &&
+			!strings.Contains(err.Error(), "unsupported type conversion") {
+			t.Fail()
+		}
+	}
+
+	// leaves valid receiver zeroed upon nil
+	x := e
+	if err := x.Scan(nil); err != nil || x != Nil {
+		t.Fail()
+	}
+	var nilPtr *Id
+	if nilPtr.Scan(nil) == nil {
+		t.Fail()
+	}
+}
+
+// Encodes entropy field as fixed-width Base36 token
+func TestEntropyToken(t *testing.T) {
+	cases := []struct {
+		entropy  uint32
+		expected string
+	}{
+		{0, "0000000"},
+		{35, "000000z"},
+		{36, "0000010"},
+		{0xdeadbeef, "1ps9wxb"},
+		{maxUint32, "1z141z3"},
+	}
+	for _, e := range cases {
+		x := FromFields(0x0123_4567_89ab, 0x123456, 0xabcdef, e.entropy)
+		if x.EntropyToken() != e.expected {
+			t.Errorf("got %s, want %s", x.EntropyToken(), e.expected)
+		}
+	}
+
+	g := NewGenerator()
+	for i := 0; i < 1_000; i++ {
+		x, _ := g.Generate()
+		token := x.EntropyToken()
+		n, err := strconv.ParseUint(token, 36, 32)
+		if err != nil || len(token) != 7 || uint32(n) != x.Entropy() {
+			t.Fail()
+		}
+	}
+}
+
+// Dumps each field value and the decoded time
+func TestDump(t *testing.T) {
+	x := FromFields(1690000000123, 0x123456, 0xabcdef, 0xdeadbeef)
+	dump := x.Dump()
+	for _, e := range []string{
+		"timestamp=1690000000123",
+		"(2023-07-22T04:26:40.123Z)",
+		"counter_hi=1193046",
+		"counter_lo=11259375",
+		"entropy=3735928559",
+	} {
+		if !strings.Contains(dump, e) {
+			t.Fail()
+		}
+	}
+}
+
+// Rejects binary input neither 16-byte integer nor 25-digit text
+func TestUnmarshalBinaryValidation(t *testing.T) {
+	e := FromFields(0x0123_4567_89ab, 0x123456, 0xabcdef, 0xdeadbeef)
+	text, _ := e.MarshalText()
+	hex := []byte(fmt.Sprintf("%x", e[:]))
+	cases := [][]byte{
+		nil,
+		{},
+		e[:15],
+		append(e[:], 0),
+		text[:24],
+		append(text, '0'),
+		hex,
+		[]byte("0x" + string(hex)),
+		[]byte("01234567-89ab-cdef-0123-456789abcdef"),
+	}
+
+	for _, c := range cases {
+		x := e
+		err := x.UnmarshalBinary(c)
+		if err == nil || x != e ||
+			!strings.Contains(err.Error(), fmt.Sprintf("%d bytes", len(c))) {
+			t.Fail()
+		}
+	}
+
+	var x Id
+	if x.UnmarshalBinary(bytes.ToUpper(text)) != nil || x != e {
+		t.Fail()
+	}
+	if x.UnmarshalBinary([]byte("zzzzzzzzzzzzzzzzzzzzzzzzz")) == nil {
+		t.Fail()
+	}
+}
+
+// Marshals and unmarshals JSON explicitly
+func TestJSON(t *testing.T) {
+	g := NewGenerator()
+	for i := 0; i < 1_000; i++ {
+		obj, _ := g.Generate()
+		marshaled, err := obj.MarshalJSON()
+		if err != nil || string(marshaled) != `"`+obj.String()+`"` {
+			t.Fail()
+		}
+
+		var unmarshaled Id
+		if unmarshaled.UnmarshalJSON(marshaled) != nil || unmarshaled != obj {
+			t.Fail()
+		}
+	}
+
+	x := FromFields(1, 2, 3, 4)
+	if x.UnmarshalJSON([]byte(" null ")) != nil || x != FromFields(1, 2, 3, 4) {
+		t.Fail()
+	}
+	if x.UnmarshalJSON([]byte(`"\u0030`+x.String()[1:]+`"`)) != nil ||
+		x != FromFields(1, 2, 3, 4) {
+		t.Fail()
+	}
+	for _, e := range []string{
+		``,
+		`0`,
+		`[]`,
+		`"036z8puq4tsxsigk6o19y164q`,
+		`"036z8puq4tsxsigk6o19y164"`,
+		`036z8puq4tsxsigk6o19y164q`,
+		`[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0]`,
+		`[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0]`,
+		`[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,256]`,
+		`[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,-1]`,
+		`[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,1.0]`,
+		`[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,1e1]`,
+		`[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,"1"]`,
+		`[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,null]`,
+		`[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0`,
+	} {
+		if x.UnmarshalJSON([]byte(e)) == nil || x != FromFields(1, 2, 3, 4) {
+			t.Errorf("accepted %s", e)
+		}
+	}
+}
+
+// Unmarshals JSON array of 16 byte values
+func TestUnmarshalJSONArray(t *testing.T) {
+	g := NewGenerator()
+	for i := 0; i < 1_000; i++ {
+		obj, _ := g.Generate()
+		elems := make([]string, 16)
+		for j, e := range obj {
+			elems[j] = fmt.Sprint(e)
+		}
+
+		var x, y Id
+		if x.UnmarshalJSON([]byte("["+strings.Join(elems, ",")+"]")) != nil ||
+			x != obj {
+			t.Fail()
+		}
+		if y.UnmarshalJSON([]byte(" [ "+strings.Join(elems, " , ")+" ] ")) != nil ||
+			y != obj {
+			t.Fail()
+		}
+
+		// embedded in struct
+		var z struct{ Id Id }
+		data := `{"Id":[` + strings.Join(elems, ",") + `]}`
+		if json.Unmarshal([]byte(data), &z) != nil || z.Id != obj {
+			t.Fail()
+		}
+	}
+
+	var x Id
+	if x.UnmarshalJSON([]byte(`[0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,255]`)) != nil ||
+		x != FromFields(0, 0, 0, 255) {
+		t.Fail()
+	}
+}
+
+// Decodes arbitrary text without panicking and round-trips accepted values
+func FuzzUnmarshalText(f *testing.F) {
+	f.Add([]byte("036z8puq4tsxsigk6o19y164q"))
+	f.Add([]byte("F5LXX1ZZ5PNORYNQGLHZMSP33"))
+	f.Add([]byte("f5lxx1zz5pnorynqglhzmsp34"))
+	f.Add([]byte("zzzzzzzzzzzzzzzzzzzzzzzzz"))
+	f.Add([]byte("0000000000000000000000000"))
+	f.Add([]byte(""))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x Id
+		if x.UnmarshalText(data) != nil {
+			return
+		}
+		marshaled, err := x.MarshalText()
+		if err != nil || !bytes.Equal(marshaled, bytes.ToLower(data)) {
+			t.Errorf("%q: round-tripped to %q", data, marshaled)
+		}
+		expected, _ := new(big.Int).SetString(string(data), 36)
+		if new(big.Int).SetBytes(x[:]).Cmp(expected) != 0 {
+			t.Errorf("%q: decoded to %x", data, x[:])
+		}
+	})
+}
+
+// Decodes arbitrary bytes without panicking and round-trips accepted values
+func FuzzUnmarshalBinary(f *testing.F) {
+	f.Add([]byte("036z8puq4tsxsigk6o19y164q"))
+	f.Add(make([]byte, 16))
+	f.Add(bytes.Repeat([]byte{0xff}, 16))
+	f.Add([]byte(""))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x Id
+		if x.UnmarshalBinary(data) != nil {
+			return
+		}
+		var y Id
+		marshaledBinary, _ := x.MarshalBinary()
+		marshaledText, _ := x.MarshalText()
+		if y.UnmarshalBinary(marshaledBinary) != nil || y != x ||
+			y.UnmarshalText(marshaledText) != nil || y != x {
+			t.Errorf("%q: failed to round-trip", data)
+		}
+		if len(data) == 16 && !bytes.Equal(marshaledBinary, data) {
+			t.Errorf("%q: round-tripped to %q", data, marshaledBinary)
+		}
+	})
+}
+
+// Ensures compliance with interfaces.
+func TestInterfaces(t *testing.T) {
+	var x Id
+	var _ fmt.Stringer = x
+	var _ fmt.Formatter = x
+	var _ encoding.TextMarshaler = x
+	var _ encoding.TextUnmarshaler = &x
+	var _ encoding.BinaryMarshaler = x
+	var _ encoding.BinaryUnmarshaler = &x
+	var _ json.Marshaler = x
+	var _ json.Unmarshaler = &x
+	var _ sql.Scanner = &x
+}
+
+func BenchmarkAppendBinary(b *testing.B) {
+	ids := make([]Id, 1_000)
+	g := NewGenerator()
+	for i := range ids {
+		ids[i], _ = g.Generate()
+	}
+	buffer := make([]byte, 0, 16*len(ids))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buffer = buffer[:0]
+		for _, e := range ids {
+			buffer, _ = e.AppendBinary(buffer)
+		}
+	}
+}
+
+func BenchmarkAppendString(b *testing.B) {
+	ids := make([]Id, 1_000)
+	g := NewGenerator()
+	for i := range ids {
+		ids[i], _ = g.Generate()
+	}
+	buffer := make([]byte, 0, 26*len(ids))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buffer = buffer[:0]
+		for _, e := range ids {
+			buffer = append(e.AppendString(buffer), ' ')
+		}
+	}
+}
+
+func BenchmarkStringConcat(b *testing.B) {
+	ids := make([]Id, 1_000)
+	g := NewGenerator()
+	for i := range ids {
+		ids[i], _ = g.Generate()
+	}
+	buffer := make([]byte, 0, 26*len(ids))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buffer = buffer[:0]
+		for _, e := range ids {
+			buffer = append(append(buffer, e.String()...), ' ')
+		}
+	}
+}
+
+// Prepares string representations of generated IDs converted by `f`
+func newBenchmarkStrings(f func(string) string) []string {
+	strs := make([]string, 1_000)
+	g := NewGenerator()
+	for i := range strs {
+		x, _ := g.Generate()
+		strs[i] = f(x.String())
+	}
+	return strs
+}
+
+func BenchmarkParseLowercase(b *testing.B) {
+	strs := newBenchmarkStrings(strings.ToLower)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, e := range strs {
+			if _, err := Parse(e); err != nil {
+				b.Fail()
+			}
+		}
+	}
+}
+
+func BenchmarkParseUppercase(b *testing.B) {
+	strs := newBenchmarkStrings(strings.ToUpper)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for _, e := range strs {
+			if _, err := Parse(e); err != nil {
+				b.Fail()
+			}
+		}
+	}
+}
+
+func BenchmarkEqualString(b *testing.B) {
+	ids := newBenchmarkIds()[:1_000]
+	strs := newBenchmarkStrings(strings.ToLower)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i, e := range ids {
+			if e.EqualString(strs[i]) {
+				b.Fail()
+			}
+		}
+	}
+}
+
+func BenchmarkStringEquality(b *testing.B) {
+	ids := newBenchmarkIds()[:1_000]
+	strs := newBenchmarkStrings(strings.ToLower)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i, e := range ids {
+			if e.String() == strs[i] {
+				b.Fail()
+			}
+		}
+	}
+}
+
+// Prepares a million-element slice of increasing IDs for benchmarks
+func newBenchmarkIds() []Id {
+	ids := make([]Id, 1_000_000)
+	g := NewGenerator()
+	for i := range ids {
+		ids[i], _ = g.Generate()
+	}
+	return ids
+}
+
+func BenchmarkCmpValue(b *testing.B) {
+	ids := newBenchmarkIds()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i := 1; i < len(ids); i++ {
+			if ids[i-1].Cmp(ids[i]) >= 0 {
+				b.Fail()
+			}
+		}
+	}
+}
+
+func BenchmarkCmpPtr(b *testing.B) {
+	ids := newBenchmarkIds()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i := 1; i < len(ids); i++ {
+			if ids[i-1].CmpPtr(&ids[i]) >= 0 {
+				b.Fail()
+			}
+		}
+	}
 }
@@ -0,0 +1,30 @@
+package scru128
+
+// Creates a generator object that uses a deterministic incrementing counter in
+// place of a random number generator.
+//
+// WARNING: The IDs generated by this generator are NOT secure and NOT globally
+// unique. Every generator created by this function produces the same sequence
+// of "random" numbers (0, 1, 2, ... as 32-bit big-endian integers), so the
+// counter_hi, counter_lo, and entropy fields are entirely predictable. Use this
+// generator only for local testing where reproducibility matters or where
+// crypto/rand is unavailable, and never in production.
+func NewInsecureCountingGenerator(opts ...GeneratorOption) *Generator {
+	return NewGeneratorWithRng(&insecureCounter{}, opts...)
+}
+
+// An io.Reader that yields a stream of incrementing 32-bit big-endian integers.
+type insecureCounter struct {
+	// The number of bytes read so far.
+	pos uint64
+}
+
+// See io.Reader
+func (r *insecureCounter) Read(p []byte) (n int, err error) {
+	for i := range p {
+		value := uint32(r.pos / 4)
+		p[i] = byte(value >> (24 - 8*(r.pos%4)))
+		r.pos++
+	}
+	return len(p), nil
+}
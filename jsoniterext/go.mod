@@ -0,0 +1,15 @@
+module github.com/scru128/go-scru128/v3/jsoniterext
+
+go 1.20
+
+require (
+	github.com/json-iterator/go v1.1.12
+	github.com/scru128/go-scru128/v3 v3.0.2
+)
+
+require (
+	github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421 // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
+)
+
+replace github.com/scru128/go-scru128/v3 => ../
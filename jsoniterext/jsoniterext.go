@@ -0,0 +1,61 @@
+// Package jsoniterext registers a SCRU128 ID codec with json-iterator.
+//
+// The codec encodes [scru128.Id] values as JSON strings containing the 25-digit
+// canonical representation, consistently with the encoding/json package. This
+// package lives in a separate module so that the core package does not depend
+// on json-iterator.
+//
+//	import "github.com/scru128/go-scru128/v3/jsoniterext"
+//
+//	func init() {
+//		jsoniterext.Register()
+//	}
+package jsoniterext
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/scru128/go-scru128/v3"
+)
+
+var registerOnce sync.Once
+
+// Registers the SCRU128 ID codec with json-iterator.
+//
+// Because json-iterator maintains type codecs globally, the codec affects all
+// jsoniter.API instances, including ones created before calling this function.
+// It is safe to call this function multiple times.
+func Register() {
+	registerOnce.Do(func() {
+		typ := reflect.TypeOf(scru128.Id{}).String()
+		jsoniter.RegisterTypeEncoderFunc(typ, encode, nil)
+		jsoniter.RegisterTypeDecoderFunc(typ, decode)
+	})
+}
+
+// Writes a SCRU128 ID as a JSON string.
+func encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	// MarshalText always returns the canonical lowercase form and, unlike
+	// AppendString, is available in all v3 releases
+	text, _ := (*scru128.Id)(ptr).MarshalText()
+	stream.WriteString(string(text))
+}
+
+// Reads a SCRU128 ID from a JSON string.
+func decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	if iter.WhatIsNext() != jsoniter.StringValue {
+		iter.ReportError("decode scru128.Id", "expected JSON string")
+		iter.Skip()
+		return
+	}
+	id, err := scru128.Parse(iter.ReadString())
+	if err != nil {
+		iter.ReportError("decode scru128.Id", fmt.Sprint(err))
+		return
+	}
+	*(*scru128.Id)(ptr) = id
+}
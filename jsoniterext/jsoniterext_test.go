@@ -0,0 +1,65 @@
+package jsoniterext
+
+import (
+	"encoding/json"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/scru128/go-scru128/v3"
+)
+
+type record struct {
+	Id   scru128.Id  `json:"id"`
+	Ptr  *scru128.Id `json:"ptr"`
+	Name string      `json:"name"`
+}
+
+// Encodes and decodes struct with Id field as canonical string
+func TestRegister(t *testing.T) {
+	Register()
+	Register()
+
+	for _, api := range []jsoniter.API{
+		jsoniter.ConfigDefault,
+		jsoniter.ConfigCompatibleWithStandardLibrary,
+		jsoniter.ConfigFastest,
+	} {
+		x := scru128.New()
+		y := scru128.New()
+		src := record{x, &y, "foo"}
+
+		marshaled, err := api.Marshal(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected, _ := json.Marshal(src)
+		if string(marshaled) != string(expected) {
+			t.Errorf("got %s, want %s", marshaled, expected)
+		}
+
+		var dst record
+		if err := api.Unmarshal(marshaled, &dst); err != nil {
+			t.Fatal(err)
+		}
+		if dst.Id != x || dst.Ptr == nil || *dst.Ptr != y || dst.Name != "foo" {
+			t.Fail()
+		}
+	}
+}
+
+// Rejects invalid JSON values
+func TestDecodeInvalid(t *testing.T) {
+	Register()
+
+	for _, e := range []string{
+		`{"id":"036z8puq4tsxsigk6o19y164"}`,
+		`{"id":"zzzzzzzzzzzzzzzzzzzzzzzzz"}`,
+		`{"id":42}`,
+		`{"id":[]}`,
+	} {
+		var dst record
+		if jsoniter.Unmarshal([]byte(e), &dst) == nil {
+			t.Errorf("accepted %s", e)
+		}
+	}
+}
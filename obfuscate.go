@@ -0,0 +1,39 @@
+package scru128
+
+import "crypto/aes"
+
+// Returns an obfuscated 25-digit string representation of the object that
+// does not reveal the creation time or order of IDs.
+//
+// This method encrypts the 128-bit value as a single AES-128 block with `key`
+// and encodes the result in the same 25-digit Base36 format as [Id.String].
+// Because AES is a keyed permutation, each ID maps to a distinct obfuscated
+// string, and [Deobfuscate] recovers the original ID with the same key.
+//
+// Obfuscation is NOT authentication: anyone can submit an arbitrary string
+// that deobfuscates into some ID, so the recovered ID must be validated (e.g.,
+// looked up in a database) before being trusted. Keep `key` secret on the
+// server side.
+func (bs Id) Obfuscate(key [16]byte) string {
+	block, _ := aes.NewCipher(key[:]) // never fails with 16-byte key
+	var dst Id
+	block.Encrypt(dst[:], bs[:])
+	return dst.String()
+}
+
+// Recovers the original SCRU128 ID object from a string produced by
+// [Id.Obfuscate] with the same `key`.
+//
+// This function returns an error only if `s` is not a valid 25-digit
+// representation; a string not produced by [Id.Obfuscate] or a wrong `key`
+// silently results in an unrelated ID.
+func Deobfuscate(s string, key [16]byte) (Id, error) {
+	src, err := Parse(s)
+	if err != nil {
+		return Id{}, err
+	}
+	block, _ := aes.NewCipher(key[:]) // never fails with 16-byte key
+	var dst Id
+	block.Decrypt(dst[:], src[:])
+	return dst, nil
+}
@@ -0,0 +1,48 @@
+package scru128
+
+import "testing"
+
+// Obfuscates and deobfuscates IDs with fixed key
+func TestObfuscate(t *testing.T) {
+	key := [16]byte{
+		0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07,
+		0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+	}
+
+	// FIPS-197 Appendix C.1 test vector
+	plain := FromUint64Pair(0x0011_2233_4455_6677, 0x8899_aabb_ccdd_eeff)
+	cipher := FromUint64Pair(0x69c4_e0d8_6a7b_0430, 0xd8cd_b780_70b4_c55a)
+	if plain.Obfuscate(key) != cipher.String() {
+		t.Fail()
+	}
+
+	g := NewGenerator()
+	ascending := 0
+	var prev string
+	for i := 0; i < 1_000; i++ {
+		e, _ := g.Generate()
+		s := e.Obfuscate(key)
+		if len(s) != 25 || s == e.String() {
+			t.Fail()
+		}
+		if x, err := Deobfuscate(s, key); err != nil || x != e {
+			t.Fail()
+		}
+		if x, err := Deobfuscate(s, [16]byte{}); err != nil || x == e {
+			t.Fail()
+		}
+		if s > prev {
+			ascending++
+		}
+		prev = s
+	}
+
+	// obfuscated strings should not preserve order
+	if ascending > 600 || ascending < 400 {
+		t.Fail()
+	}
+
+	if _, err := Deobfuscate("zzzzzzzzzzzzzzzzzzzzzzzzz", key); err == nil {
+		t.Fail()
+	}
+}
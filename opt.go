@@ -0,0 +1,176 @@
+package scru128
+
+import "time"
+
+// Represents an option that customizes the behavior of a [Generator]. Pass one
+// or more options to [NewGenerator], [NewGeneratorWithRng], or
+// [NewGeneratorChecked].
+type GeneratorOption func(g *Generator)
+
+// The minimum value accepted by [WithCounterHiRenewalInterval].
+const minCounterHiRenewalInterval = 1
+
+// The maximum value accepted by [WithCounterHiRenewalInterval].
+const maxCounterHiRenewalInterval = 60_000 // 1 minute
+
+// The maximum number of entropy bits reserved by [WithNodeBits].
+const maxNodeBits = 16
+
+// Sets the interval in milliseconds at which the generator renews the
+// counter_hi field with a random number. The default is `1_000` (one second).
+//
+// The SCRU128 specification recommends renewing counter_hi roughly once per
+// second so that IDs generated within a second remain unpredictable while
+// those spanning seconds do not reveal the number of IDs generated in between.
+// A shorter interval increases unpredictability at the cost of additional
+// random number generator reads, and a longer interval does the opposite.
+//
+// This option panics if `ms` is not in the range of 1 to 60,000 (one minute).
+func WithCounterHiRenewalInterval(ms uint64) GeneratorOption {
+	if ms < minCounterHiRenewalInterval || ms > maxCounterHiRenewalInterval {
+		panic("`ms` out of recommended range")
+	}
+	return func(g *Generator) {
+		g.counterHiRenewalInterval = ms
+	}
+}
+
+// Disables the internal mutex that the thread-safe methods such as
+// [Generator.Generate] and [Generator.GenerateOrAbort] acquire.
+//
+// This option offers a performance escape hatch for strictly single-threaded
+// use, e.g., a dedicated goroutine that generates all IDs. The generator with
+// this option is NOT thread-safe at all; concurrent use of any method results
+// in race conditions.
+func WithoutLocking() GeneratorOption {
+	return func(g *Generator) {
+		g.noLock = true
+	}
+}
+
+// Sets a custom epoch in Unix milliseconds, e.g., the launch date of a project,
+// from which the generator measures the 48-bit timestamp field instead of the
+// Unix epoch.
+//
+// A recent epoch extends the lifetime of the 48-bit timestamp and hides the
+// absolute time from observers of IDs. Note that this is a non-standard
+// extension: the IDs are still valid SCRU128 IDs but their timestamps do not
+// represent Unix time, so [Id.Time] and other implementations of SCRU128
+// decode wrong times from them. Use [Id.TimeWithEpoch] with the same epoch to
+// decode the wall-clock time, and do not mix IDs generated with different
+// epochs because they do not sort in the order of generation.
+//
+// This option affects the methods that read the wall clock and
+// [Generator.GenerateForTimestamp], while the `Core` methods embed the
+// `timestamp` argument as is. If the wall clock steps back to or before the
+// epoch after construction, the methods that read the wall clock use the
+// smallest valid timestamp (one) instead, which the generator handles as a
+// clock rollback: it keeps the previous timestamp if the rollback is within
+// the allowance, and otherwise resets or aborts as documented in [Generator].
+//
+// This option panics if `epochUnixMilli` is not strictly in the past.
+func WithEpoch(epochUnixMilli uint64) GeneratorOption {
+	if epochUnixMilli >= uint64(time.Now().UnixMilli()) {
+		panic("`epochUnixMilli` not in the past")
+	}
+	return func(g *Generator) {
+		g.epoch = epochUnixMilli
+	}
+}
+
+// Reserves the top `bits` bits of the 32-bit entropy field for a fixed
+// `nodeId`, e.g., a crude shard tag in distributed deployments without a
+// separate coordination service, and fills the remaining bits with random
+// numbers.
+//
+// Note that this option reduces the entropy of IDs by `bits` bits, which
+// makes IDs more predictable and, for IDs generated by the same node within
+// the same millisecond, increases the risk of collision. The node id also
+// reveals which node generated an ID. Up to 16 bits can be reserved so that
+// at least 16 bits of entropy remain.
+//
+// This option panics if `bits` is greater than 16 or if `nodeId` does not fit
+// in `bits` bits.
+func WithNodeBits(nodeId uint32, bits uint8) GeneratorOption {
+	if bits > maxNodeBits {
+		panic("`bits` out of range")
+	} else if nodeId>>bits != 0 {
+		panic("`nodeId` out of `bits`-bit range")
+	}
+	return func(g *Generator) {
+		g.nodeId = nodeId
+		g.nodeBits = bits
+	}
+}
+
+// Makes the generator advance the timestamp field by at least one millisecond
+// for every ID, so that consecutive IDs from the generator always have strictly
+// increasing timestamps, for downstream systems that order IDs solely by the
+// timestamp.
+//
+// Instead of incrementing the counters, the generator with this option uses
+// the previous timestamp plus one if the current one is not greater than that.
+// Consequently, the timestamp drifts ahead of the wall clock while the
+// generator produces more than one ID per millisecond on average. If the drift
+// exceeds the rollback allowance (ten seconds by default), the wall clock is
+// regarded as a significant clock rollback.
+//
+// To keep the timestamps strictly increasing, the generator with this option
+// never resets upon significant clock rollback; the `Generate` (OrReset)
+// methods, such as [Generator.Generate], return the [ErrClockRollback] err as
+// the `OrAbort` variants do, until the wall clock catches up with the
+// timestamp of the immediately preceding ID.
+func WithStrictTimestampMonotonicity() GeneratorOption {
+	return func(g *Generator) {
+		g.strictTimestamp = true
+	}
+}
+
+// Represents a set of hooks that a [Generator] configured by [WithMetrics]
+// invokes upon generation events, e.g., to increment Prometheus counters
+// without making this package depend on a metrics library.
+//
+// The methods are called while the generator is locked (if it is), so they
+// should return quickly and must not call the methods of the generator.
+type Metrics interface {
+	// Called when a new ID is generated successfully.
+	IncGenerated()
+
+	// Called when the generator detects a significant clock rollback, whether
+	// the generator aborts or resets subsequently.
+	IncRollback()
+
+	// Called when the counters overflow and the generator increments the
+	// timestamp ahead of the wall clock.
+	IncCounterOverflow()
+}
+
+// Sets the metrics hooks that the generator notifies of generation events.
+//
+// This option panics if `m` is nil.
+func WithMetrics(m Metrics) GeneratorOption {
+	if m == nil {
+		panic("option called with nil `m`")
+	}
+	return func(g *Generator) {
+		g.metrics = m
+	}
+}
+
+// Makes the generator constructor sample the first several random values from
+// the random number generator and reject it if all the bytes read are equal,
+// e.g., an all-zero reader resulting from misconfiguration.
+//
+// The check is a heuristic against obviously broken random number generators
+// only. It cannot detect a random number generator that is predictable,
+// repeats a pattern longer than a byte, or degrades after construction, and
+// it does not assess the statistical quality of entropy. The sampled values
+// are discarded.
+//
+// [NewGeneratorChecked] returns an error if the check fails, while
+// [NewGenerator] and [NewGeneratorWithRng] panic.
+func WithEntropyHealthCheck() GeneratorOption {
+	return func(g *Generator) {
+		g.entropyHealthCheck = true
+	}
+}
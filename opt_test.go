@@ -0,0 +1,307 @@
+package scru128
+
+import (
+	"bytes"
+	crand "crypto/rand"
+	"errors"
+	"testing"
+	"time"
+)
+
+// Renews counter_hi at the configured interval
+func TestWithCounterHiRenewalInterval(t *testing.T) {
+	var ts uint64 = 0x0123_4567_89ab
+	for _, interval := range []uint64{1, 10, 1_000, 5_000} {
+		g := NewGenerator(WithCounterHiRenewalInterval(interval))
+		renewals := 0
+		prev, _ := g.GenerateOrAbortCore(ts, 10_000)
+		for i := uint64(1); i <= 10_000; i++ {
+			curr, _ := g.GenerateOrAbortCore(ts+i, 10_000)
+			if curr.CounterHi() != prev.CounterHi() {
+				renewals++
+				if (i % interval) != 0 {
+					t.Fail()
+				}
+			}
+			prev = curr
+		}
+		// allow a few coincidences where renewal yields same value
+		if expected := int(10_000 / interval); renewals > expected ||
+			renewals < expected-1 {
+			t.Fail()
+		}
+	}
+}
+
+// Rejects counter_hi renewal interval out of range
+func TestWithCounterHiRenewalIntervalRange(t *testing.T) {
+	for _, e := range []uint64{0, 60_001, maxTimestamp} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fail()
+				}
+			}()
+			WithCounterHiRenewalInterval(e)
+		}()
+	}
+}
+
+// Generates increasing IDs without lock
+func TestWithoutLocking(t *testing.T) {
+	g := NewGenerator(WithoutLocking())
+	if !g.noLock || !g.Clone().noLock {
+		t.Fail()
+	}
+
+	// lock is never touched, so holding it does not block
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	prev, _ := g.Generate()
+	for i := 0; i < 1_000; i++ {
+		curr, err := g.GenerateOrAbort()
+		if err != nil || prev.Cmp(curr) >= 0 {
+			t.Fail()
+		}
+		prev = curr
+	}
+}
+
+func BenchmarkGeneratorWithLocking(b *testing.B) {
+	g := NewGeneratorWithRng(&insecureCounter{})
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Generate()
+	}
+}
+
+func BenchmarkGeneratorWithoutLocking(b *testing.B) {
+	g := NewGeneratorWithRng(&insecureCounter{}, WithoutLocking())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		g.Generate()
+	}
+}
+
+// Measures timestamp from custom epoch
+func TestWithEpoch(t *testing.T) {
+	epoch := uint64(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC).UnixMilli())
+	g := NewGenerator(WithEpoch(epoch))
+	before := time.Now().Truncate(time.Millisecond)
+	x, err := g.Generate()
+	after := time.Now()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.TimeWithEpoch(epoch).Before(before) || x.TimeWithEpoch(epoch).After(after) {
+		t.Fail()
+	}
+	if x.Timestamp() >= uint64(before.UnixMilli()) {
+		t.Fail()
+	}
+	if x.TimeWithEpoch(0) != x.Time() {
+		t.Fail()
+	}
+
+	ts := uint64(before.UnixMilli())
+	y, err := g.GenerateForTimestamp(ts)
+	if err != nil || y.Timestamp() < ts-epoch || y.Cmp(x) <= 0 {
+		t.Fail()
+	}
+	if z := g.Clone(); z.epoch != epoch {
+		t.Fail()
+	}
+
+	// clamps timestamp if wall clock steps back before epoch
+	g.epoch = uint64(time.Now().Add(time.Hour).UnixMilli())
+	if w, err := g.GenerateOrAbort(); err != ErrClockRollback || w != Nil {
+		t.Fail()
+	}
+	w, err := g.Generate()
+	if err != nil || w.Timestamp() != 1 {
+		t.Fail()
+	}
+	if w, err = g.Generate(); err != nil || w.Timestamp() != 1 {
+		t.Fail()
+	}
+
+	for _, e := range []time.Time{time.Now().Add(time.Hour), time.Now()} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fail()
+				}
+			}()
+			WithEpoch(uint64(e.UnixMilli()))
+		}()
+	}
+}
+
+// Embeds node id in top bits of entropy
+func TestWithNodeBits(t *testing.T) {
+	for _, e := range []struct {
+		nodeId uint32
+		bits   uint8
+	}{{0, 0}, {1, 1}, {0x2a, 8}, {0xbeef, 16}, {0, 16}} {
+		g := NewGenerator(WithNodeBits(e.nodeId, e.bits))
+		var lowerBits uint32
+		for i := 0; i < 1_000; i++ {
+			x, _ := g.Generate()
+			if e.bits > 0 && x.Entropy()>>(32-e.bits) != e.nodeId {
+				t.Fail()
+			}
+			lowerBits |= x.Entropy() & (maxUint32 >> e.bits)
+		}
+		// remaining entropy varies
+		if lowerBits != maxUint32>>e.bits {
+			t.Fail()
+		}
+	}
+
+	for _, e := range []struct {
+		nodeId uint32
+		bits   uint8
+	}{{0, 17}, {2, 1}, {0x1_0000, 16}, {1, 0}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fail()
+				}
+			}()
+			WithNodeBits(e.nodeId, e.bits)
+		}()
+	}
+}
+
+// Advances timestamp for every ID
+func TestWithStrictTimestampMonotonicity(t *testing.T) {
+	g := NewGenerator(WithStrictTimestampMonotonicity())
+	prev, _ := g.Generate()
+	for i := 0; i < 1_000; i++ {
+		curr, err := g.Generate()
+		if err != nil || curr.Timestamp() <= prev.Timestamp() {
+			t.Fail()
+		}
+		prev = curr
+	}
+
+	var ts uint64 = 0x0123_4567_89ab
+	h := NewGenerator(WithStrictTimestampMonotonicity())
+	for i := uint64(0); i < 100; i++ {
+		x, _ := h.GenerateOrAbortCore(ts, 10_000)
+		if x.Timestamp() != ts+i {
+			t.Fail()
+		}
+	}
+	if _, err := h.GenerateOrAbortCore(ts-10_000+99, 10_000); err != nil {
+		t.Fail()
+	}
+	if _, err := h.GenerateOrAbortCore(ts-10_000+99, 10_000); err != ErrClockRollback {
+		t.Fail()
+	}
+
+	// does not reset upon significant rollback
+	if _, err := h.GenerateOrResetCore(ts-10_000+99, 10_000); err != ErrClockRollback {
+		t.Fail()
+	}
+	if x, err := h.GenerateOrResetCore(ts+100, 10_000); err != nil || x.Timestamp() != ts+101 {
+		t.Fail()
+	}
+	h.timestamp = uint64(time.Now().Add(time.Minute).UnixMilli())
+	if _, err := h.Generate(); err != ErrClockRollback {
+		t.Fail()
+	}
+
+	h.timestamp = maxTimestamp
+	if _, err := h.GenerateOrAbortCore(maxTimestamp, 10_000); err != ErrTimestampOverflow {
+		t.Fail()
+	}
+}
+
+// Records generation events
+type fakeMetrics struct {
+	generated, rollback, counterOverflow int
+}
+
+func (m *fakeMetrics) IncGenerated()       { m.generated++ }
+func (m *fakeMetrics) IncRollback()        { m.rollback++ }
+func (m *fakeMetrics) IncCounterOverflow() { m.counterOverflow++ }
+
+// Notifies metrics hooks of generation events
+func TestWithMetrics(t *testing.T) {
+	var ts uint64 = 0x0123_4567_89ab
+	m := &fakeMetrics{}
+	g := NewGenerator(WithMetrics(m))
+	for i := 0; i < 100; i++ {
+		g.GenerateOrAbortCore(ts, 10_000)
+	}
+	if *m != (fakeMetrics{100, 0, 0}) {
+		t.Fail()
+	}
+
+	g.counterHi, g.counterLo = maxCounterHi, maxCounterLo
+	g.GenerateOrAbortCore(ts, 10_000)
+	if *m != (fakeMetrics{101, 0, 1}) {
+		t.Fail()
+	}
+
+	g.GenerateOrAbortCore(ts-20_000, 10_000)
+	if *m != (fakeMetrics{101, 1, 1}) {
+		t.Fail()
+	}
+	g.GenerateOrResetCore(ts-20_000, 10_000)
+	if *m != (fakeMetrics{102, 2, 1}) {
+		t.Fail()
+	}
+
+	if h := g.Clone(); h.metrics != m {
+		t.Fail()
+	}
+}
+
+// Rejects random number generator returning constant bytes
+func TestWithEntropyHealthCheck(t *testing.T) {
+	for _, e := range []byte{0x00, 0xab, 0xff} {
+		rng := bytes.NewReader(bytes.Repeat([]byte{e}, 64))
+		if g, err := NewGeneratorChecked(rng, WithEntropyHealthCheck()); g != nil ||
+			err != ErrDegenerateEntropy {
+			t.Fail()
+		}
+	}
+
+	func() {
+		defer func() {
+			if recover() != ErrDegenerateEntropy {
+				t.Fail()
+			}
+		}()
+		NewGeneratorWithRng(bytes.NewReader(make([]byte, 64)), WithEntropyHealthCheck())
+	}()
+
+	// reports error of random number generator
+	if _, err := NewGeneratorChecked(bytes.NewReader(nil), WithEntropyHealthCheck()); err == nil ||
+		errors.Is(err, ErrDegenerateEntropy) {
+		t.Fail()
+	}
+
+	// does not check without option
+	g, err := NewGeneratorChecked(bytes.NewReader(make([]byte, 64)))
+	if g == nil || err != nil {
+		t.Fail()
+	}
+
+	g, err = NewGeneratorChecked(crand.Reader, WithEntropyHealthCheck())
+	if g == nil || err != nil {
+		t.Fail()
+	}
+	if _, err := g.Generate(); err != nil {
+		t.Fail()
+	}
+	NewGenerator(WithEntropyHealthCheck())
+
+	// accepts bytes that vary across reads
+	rng := bytes.NewReader(bytes.Repeat([]byte{0, 0, 0, 0, 1, 1, 1, 1}, 8))
+	if _, err := NewGeneratorChecked(rng, WithEntropyHealthCheck()); err != nil {
+		t.Fail()
+	}
+}
@@ -0,0 +1,24 @@
+package ormtype_test
+
+import (
+	"fmt"
+
+	"github.com/scru128/go-scru128/v3/ormtype"
+)
+
+func Example() {
+	type User struct {
+		ID   ormtype.Id `gorm:"primaryKey"`
+		Name string
+	}
+
+	id, _ := ormtype.Parse("036z8puq4tsxsigk6o19y164q")
+	user := User{ID: id, Name: "alice"}
+
+	value, _ := user.ID.Value()
+	fmt.Println(value)
+	fmt.Println(user.ID.GormDataType())
+	// Output:
+	// 036z8puq4tsxsigk6o19y164q
+	// char(25)
+}
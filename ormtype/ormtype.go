@@ -0,0 +1,65 @@
+// Package ormtype provides a SCRU128 ID field type that drops into ORM models
+// such as those of GORM and ent.
+//
+// The [Id] type embeds [scru128.Id] and additionally implements
+// driver.Valuer, so it is stored in and loaded from a database column as the
+// 25-digit canonical string. This package depends on no ORM library; it
+// implements the interfaces that the ORMs detect by method signatures.
+//
+// # GORM
+//
+// Use [Id] as a model field type. GORM picks up the column type from
+// [Id.GormDataType]:
+//
+//	type User struct {
+//		ID   ormtype.Id `gorm:"primaryKey"`
+//		Name string
+//	}
+//
+// # ent
+//
+// Declare a string field with [Id] as the Go type:
+//
+//	field.String("id").
+//		GoType(ormtype.Id{}).
+//		SchemaType(map[string]string{dialect.Postgres: "char(25)"}).
+//		DefaultFunc(ormtype.New)
+package ormtype
+
+import (
+	"database/sql/driver"
+
+	"github.com/scru128/go-scru128/v3"
+)
+
+// Represents a SCRU128 ID that is stored as the 25-digit canonical string in
+// databases.
+type Id struct {
+	scru128.Id
+}
+
+// Generates a new SCRU128 ID object using the global generator, or panics if
+// crypto/rand fails.
+func New() Id {
+	return Id{scru128.New()}
+}
+
+// Creates an Id object from a 25-digit string representation.
+func Parse(strValue string) (Id, error) {
+	id, err := scru128.Parse(strValue)
+	return Id{id}, err
+}
+
+// See driver.Valuer
+//
+// This method returns the 25-digit canonical string representation, which is
+// lowercase regardless of [scru128.SetStringCase].
+func (bs Id) Value() (driver.Value, error) {
+	return string(bs.AppendString(make([]byte, 0, scru128.StringLen))), nil
+}
+
+// Returns the column data type for GORM, a fixed-length string of 25
+// characters.
+func (Id) GormDataType() string {
+	return "char(25)"
+}
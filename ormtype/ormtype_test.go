@@ -0,0 +1,60 @@
+package ormtype
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+// Mirrors the GORM interface detected by method signature
+type gormDataTypeInterface interface {
+	GormDataType() string
+}
+
+// Implements GORM data type interface
+func TestGormDataType(t *testing.T) {
+	var x any = New()
+	if d, ok := x.(gormDataTypeInterface); !ok || d.GormDataType() != "char(25)" {
+		t.Fail()
+	}
+}
+
+// Converts to driver value and back via Scan
+func TestValueScan(t *testing.T) {
+	for i := 0; i < 1_000; i++ {
+		x := New()
+		v, err := x.Value()
+		if err != nil || v != x.String() || !driver.IsValue(v) {
+			t.Fail()
+		}
+
+		var y Id
+		if y.Scan(v) != nil || y != x {
+			t.Fail()
+		}
+	}
+
+	var y Id
+	if y.Scan(42) == nil {
+		t.Fail()
+	}
+}
+
+// Parses textual representation
+func TestParse(t *testing.T) {
+	x, err := Parse("036Z8PUQ4TSXSIGK6O19Y164Q")
+	if err != nil || x.String() != "036z8puq4tsxsigk6o19y164q" {
+		t.Fail()
+	}
+	if _, err := Parse("036z8puq4tsxsigk6o19y164"); err == nil {
+		t.Fail()
+	}
+}
+
+// Ensures compliance with interfaces.
+func TestInterfaces(t *testing.T) {
+	var x Id
+	var _ driver.Valuer = x
+	var _ sql.Scanner = &x
+	var _ gormDataTypeInterface = x
+}
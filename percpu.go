@@ -0,0 +1,73 @@
+package scru128
+
+import (
+	"math/bits"
+	"runtime"
+	"sync/atomic"
+)
+
+// Represents a set of generators, one per logical CPU, that spreads concurrent
+// calls across the generators to reduce lock contention.
+//
+// Unlike a single [Generator], which returns monotonically increasing IDs to
+// all callers, this type guarantees the uniqueness of IDs but NOT their global
+// order: the IDs generated within the same millisecond by different internal
+// generators sort in an arbitrary order, and even successive calls from a
+// goroutine may be routed to different generators. Use a single [Generator] if
+// the order of IDs matters.
+//
+// Each internal generator embeds its index in the top bits of the entropy field
+// as [WithNodeBits] does, so the generators never produce the same ID. This
+// reduces the entropy of IDs by the number of bits needed to represent the
+// number of generators, e.g., three bits for eight CPUs.
+//
+// This structure must be instantiated by [NewPerCPUGenerator].
+type PerCPUGenerator struct {
+	gens []*Generator
+
+	// The round-robin index used to route calls to the generators.
+	next atomic.Uint32
+}
+
+// Creates a per-CPU generator object with as many generators as
+// runtime.GOMAXPROCS(0), up to 65,536, each with the default random number
+// generator.
+//
+// The behavior of the internal generators can be customized by
+// [GeneratorOption] values, except that [WithNodeBits] is overridden and
+// [WithoutLocking] is ignored.
+func NewPerCPUGenerator(opts ...GeneratorOption) *PerCPUGenerator {
+	n := runtime.GOMAXPROCS(0)
+	if n > 1<<maxNodeBits {
+		n = 1 << maxNodeBits
+	}
+	nodeBits := uint8(bits.Len(uint(n - 1)))
+	g := &PerCPUGenerator{gens: make([]*Generator, n)}
+	for i := range g.gens {
+		o := append(opts[:len(opts):len(opts)], WithNodeBits(uint32(i), nodeBits))
+		g.gens[i] = NewGenerator(o...)
+		g.gens[i].noLock = false
+	}
+	return g
+}
+
+// Generates a new SCRU128 ID object using one of the internal generators.
+//
+// This method is thread-safe. Go does not expose the current CPU or goroutine,
+// so this method routes calls to the generators in a round-robin manner and
+// skips the generators locked by other calls. It returns a non-nil err if the
+// random number generator fails.
+func (g *PerCPUGenerator) Generate() (id Id, err error) {
+	n := uint32(len(g.gens))
+	start := g.next.Add(1)
+	for i := uint32(0); i < n; i++ {
+		gen := g.gens[(start+i)%n]
+		if !gen.lock.TryLock() {
+			continue
+		}
+		id, err = gen.GenerateOrResetCore(gen.now(), defaultRollbackAllowance)
+		gen.lock.Unlock()
+		return
+	}
+	return g.gens[start%n].Generate()
+}
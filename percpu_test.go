@@ -0,0 +1,70 @@
+package scru128
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// Generates unique IDs across many goroutines
+func TestPerCPUGenerator(t *testing.T) {
+	g := NewPerCPUGenerator(WithoutLocking())
+	if len(g.gens) != runtime.GOMAXPROCS(0) {
+		t.Fail()
+	}
+	for i, e := range g.gens {
+		if e.noLock || e.nodeId != uint32(i) || 1<<e.nodeBits < len(g.gens) {
+			t.Fail()
+		}
+	}
+
+	const goroutines, perGoroutine = 64, 10_000
+	group := new(sync.WaitGroup)
+	results := make([][]Id, goroutines)
+	for i := range results {
+		group.Add(1)
+		go func(i int) {
+			defer group.Done()
+			ids := make([]Id, perGoroutine)
+			for j := range ids {
+				x, err := g.Generate()
+				if err != nil {
+					t.Error(err)
+				}
+				ids[j] = x
+			}
+			results[i] = ids
+		}(i)
+	}
+	group.Wait()
+
+	set := make(map[Id]struct{}, goroutines*perGoroutine)
+	for _, ids := range results {
+		for _, e := range ids {
+			set[e] = struct{}{}
+		}
+	}
+	if len(set) != goroutines*perGoroutine {
+		t.Fail()
+	}
+}
+
+func BenchmarkPerCPUGeneratorParallel(b *testing.B) {
+	g := NewPerCPUGenerator()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			g.Generate()
+		}
+	})
+}
+
+func BenchmarkGeneratorParallel(b *testing.B) {
+	g := NewGenerator()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			g.Generate()
+		}
+	})
+}
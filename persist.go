@@ -0,0 +1,147 @@
+package scru128
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Represents a wrapper of [Generator] that persists the immediately preceding
+// ID to storage so that the generator restarted after a crash continues to
+// produce monotonically increasing IDs.
+//
+// The wrapper writes the 16-byte big-endian byte array of the latest ID at the
+// beginning of the storage every `flushInterval` generations and upon
+// [PersistentGenerator.Flush] or [PersistentGenerator.Close], and the
+// constructor fast-forwards the base generator to the persisted ID.
+//
+// The durability is best-effort: the IDs generated after the last flush are not
+// reflected in the storage, so the generator restarted after a crash may
+// produce IDs smaller than those. A `flushInterval` of 1 avoids this at the
+// cost of a write per generation, and whether a write survives a system crash
+// further depends on the storage (e.g., call Sync on an *os.File).
+//
+// This structure must be instantiated by [NewPersistentGenerator].
+type PersistentGenerator struct {
+	base          *Generator
+	storage       io.ReadWriteSeeker
+	flushInterval int
+
+	// The immediately preceding ID and the number of IDs generated after the
+	// last flush.
+	last    Id
+	pending int
+
+	lock sync.Mutex
+}
+
+// Creates a persistent generator object that wraps `base`, loading the
+// persisted ID from `storage` (e.g., an *os.File) if any and flushing the
+// latest ID to `storage` every `flushInterval` generations.
+//
+// An empty `storage` starts the generator fresh. This constructor returns an
+// error if reading `storage` fails or if `storage` contains fewer than 16
+// bytes.
+//
+// This constructor panics if `base` or `storage` is nil or if `flushInterval`
+// is not positive.
+func NewPersistentGenerator(
+	base *Generator,
+	storage io.ReadWriteSeeker,
+	flushInterval int,
+) (*PersistentGenerator, error) {
+	if base == nil {
+		panic("constructor called with nil `base`")
+	} else if storage == nil {
+		panic("constructor called with nil `storage`")
+	} else if flushInterval <= 0 {
+		panic("`flushInterval` must be positive")
+	}
+
+	if _, err := storage.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("scru128.PersistentGenerator: storage error: %w", err)
+	}
+	var last Id
+	_, err := io.ReadFull(storage, last[:])
+	if err == io.EOF {
+		last = Nil
+	} else if err != nil {
+		return nil, fmt.Errorf("scru128.PersistentGenerator: storage error: %w", err)
+	} else {
+		base.acquire()
+		base.fastForward(last)
+		base.release()
+	}
+	return &PersistentGenerator{
+		base:          base,
+		storage:       storage,
+		flushInterval: flushInterval,
+		last:          last,
+	}, nil
+}
+
+// Generates a new SCRU128 ID object using [Generator.GenerateOrAbort] of the
+// base generator, flushing it to the storage if `flushInterval` IDs have been
+// generated since the last flush.
+//
+// Unlike [Generator.Generate], this method never resets the generator, which
+// would produce IDs smaller than the persisted one, and instead returns the
+// [ErrClockRollback] err if the system clock is significantly behind the
+// persisted ID, e.g., after a restart on a machine with a skewed clock.
+//
+// This method is thread-safe. It returns a non-nil err if the random number
+// generator fails or if writing the storage fails, in which case the returned
+// ID is still valid.
+func (g *PersistentGenerator) Generate() (id Id, err error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	id, err = g.base.GenerateOrAbort()
+	if err != nil {
+		return Id{}, err
+	}
+	g.last = id
+	g.pending++
+	if g.pending >= g.flushInterval {
+		err = g.flush()
+	}
+	return
+}
+
+// Writes the immediately preceding ID to the storage.
+//
+// This method is thread-safe.
+func (g *PersistentGenerator) Flush() error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.flush()
+}
+
+// Flushes the immediately preceding ID to the storage and closes the storage if
+// it implements io.Closer.
+//
+// This method is thread-safe. The generator must not be used after this method
+// is called.
+func (g *PersistentGenerator) Close() error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	err := g.flush()
+	if c, ok := g.storage.(io.Closer); ok {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Writes the immediately preceding ID to the storage, assuming the lock is
+// held.
+func (g *PersistentGenerator) flush() error {
+	if _, err := g.storage.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("scru128.PersistentGenerator: storage error: %w", err)
+	}
+	if _, err := g.storage.Write(g.last[:]); err != nil {
+		return fmt.Errorf("scru128.PersistentGenerator: storage error: %w", err)
+	}
+	g.pending = 0
+	return nil
+}
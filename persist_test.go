@@ -0,0 +1,89 @@
+package scru128
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Continues monotonically after reloading persisted state
+func TestPersistentGenerator(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state")
+	var prev Id
+	for run := 0; run < 3; run++ {
+		f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		base := NewGenerator()
+		g, err := NewPersistentGenerator(base, f, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// restores state from persisted ID
+		if base.timestamp != prev.Timestamp() || base.counterLo != prev.CounterLo() {
+			t.Fail()
+		}
+
+		for i := 0; i < 25; i++ {
+			curr, err := g.Generate()
+			if err != nil || curr.Cmp(prev) <= 0 {
+				t.Fail()
+			}
+			prev = curr
+		}
+		if err := g.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		data, _ := os.ReadFile(path)
+		if Id(data) != prev {
+			t.Fail()
+		}
+	}
+}
+
+// Flushes every configured number of generations
+func TestPersistentGeneratorFlushInterval(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "state"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	g, _ := NewPersistentGenerator(NewGenerator(), f, 3)
+
+	var ids []Id
+	for i := 0; i < 7; i++ {
+		x, _ := g.Generate()
+		ids = append(ids, x)
+	}
+	data, _ := os.ReadFile(f.Name())
+	if Id(data) != ids[5] {
+		t.Fail()
+	}
+	if g.Flush() != nil {
+		t.Fail()
+	}
+	data, _ = os.ReadFile(f.Name())
+	if Id(data) != ids[6] {
+		t.Fail()
+	}
+
+	// refuses to reset upon significant clock rollback
+	future := FromFields(uint64(time.Now().UnixMilli())+60_000, 0, 0, 0)
+	f.Seek(0, io.SeekStart)
+	f.Write(future[:])
+	g, _ = NewPersistentGenerator(NewGenerator(), f, 3)
+	if _, err := g.Generate(); err != ErrClockRollback {
+		t.Fail()
+	}
+
+	// rejects truncated storage
+	f.Truncate(8)
+	if _, err := NewPersistentGenerator(NewGenerator(), f, 3); err == nil {
+		t.Fail()
+	}
+}
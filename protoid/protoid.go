@@ -0,0 +1,43 @@
+// Package protoid standardizes how SCRU128 IDs are carried in Protocol Buffers
+// messages.
+//
+// A SCRU128 ID should be declared as a `bytes` field that holds the 16-byte
+// big-endian byte array, which is the most compact representation and
+// preserves the sort order of IDs under byte-wise comparison:
+//
+//	message User {
+//	  bytes id = 1; // SCRU128 ID as 16-byte big-endian byte array
+//	  string name = 2;
+//	}
+//
+// Convert IDs from and to the field values with [ToProtoBytes] and
+// [FromProtoBytes]. This package does not depend on any Protocol Buffers
+// library, and neither does the core package.
+package protoid
+
+import (
+	"fmt"
+
+	"github.com/scru128/go-scru128/v3"
+)
+
+// Returns the 16-byte big-endian byte array to be set to a `bytes` field.
+func ToProtoBytes(id scru128.Id) []byte {
+	b, _ := id.AppendBinary(make([]byte, 0, 16))
+	return b
+}
+
+// Creates a SCRU128 ID object from the value of a `bytes` field.
+//
+// Unlike [scru128.Id.UnmarshalBinary], this function accepts only the 16-byte
+// byte array and returns an error for any other input, including an empty
+// slice that represents an unset field in proto3.
+func FromProtoBytes(b []byte) (scru128.Id, error) {
+	if len(b) != 16 {
+		return scru128.Id{}, fmt.Errorf(
+			"protoid: invalid length of bytes field: %d bytes (expected 16)", len(b))
+	}
+	var id scru128.Id
+	copy(id[:], b)
+	return id, nil
+}
@@ -0,0 +1,50 @@
+package protoid
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/scru128/go-scru128/v3"
+)
+
+// Converts from/to 16-byte bytes field value
+func TestRoundTrip(t *testing.T) {
+	cases := []scru128.Id{
+		scru128.FromFields(0, 0, 0, 0),
+		scru128.FromFields(0xffff_ffff_ffff, 0xff_ffff, 0xff_ffff, 0xffff_ffff),
+	}
+	for i := 0; i < 1_000; i++ {
+		cases = append(cases, scru128.New())
+	}
+
+	for _, e := range cases {
+		b := ToProtoBytes(e)
+		if len(b) != 16 || !bytes.Equal(b, e[:]) {
+			t.Fail()
+		}
+		if x, err := FromProtoBytes(b); err != nil || x != e {
+			t.Fail()
+		}
+
+		// not aliased to original array
+		b[0] ^= 0xff
+		if x, _ := FromProtoBytes(b); x == e {
+			t.Fail()
+		}
+	}
+}
+
+// Rejects bytes field value of invalid length
+func TestFromProtoBytesValidation(t *testing.T) {
+	for _, e := range [][]byte{
+		nil,
+		{},
+		make([]byte, 15),
+		make([]byte, 17),
+		[]byte("036z8puq4tsxsigk6o19y164q"),
+	} {
+		if _, err := FromProtoBytes(e); err == nil {
+			t.Fail()
+		}
+	}
+}
@@ -16,6 +16,17 @@
 // See SCRU128 Specification for details: https://github.com/scru128/spec
 package scru128
 
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// The length in bytes of the 25-digit canonical string representation.
+const StringLen = 25
+
+// The length in bytes of the 16-byte big-endian byte array representation.
+const BinaryLen = 16
+
 // The maximum value of 48-bit timestamp field.
 const maxTimestamp uint64 = 0xffff_ffff_ffff
 
@@ -25,21 +36,60 @@ const maxCounterHi uint32 = 0xff_ffff
 // The maximum value of 24-bit counter_lo field.
 const maxCounterLo uint32 = 0xff_ffff
 
-var globalGenerator = NewGenerator()
+// The maximum value of the 48-bit combined counter of counter_hi and
+// counter_lo fields.
+const maxCounter uint64 = uint64(maxCounterHi)<<24 | uint64(maxCounterLo)
+
+// Returns the theoretical maximum number of IDs that a generator can produce
+// within a millisecond without incrementing the timestamp ahead of the wall
+// clock, i.e., 281,474,959,933,441.
+//
+// The SCRU128 specification allots the 48-bit combined counter of counter_hi
+// and counter_lo to the IDs generated within a millisecond, which would allow
+// 2^48 (about 281 trillion) IDs. However, the generator seeds counter_lo with a
+// 24-bit random number at every new millisecond, so the value is reduced by
+// the worst-case seed (2^24 - 1) to 2^48 - 2^24 + 1. The cap further assumes
+// that counter_hi is zero, whereas the generator seeds counter_hi with a random
+// number as well; see [Generator.WouldExhaustAt] for the estimate based on the
+// state of a generator.
+func MaxRatePerMillis() uint64 {
+	return maxCounter - uint64(maxCounterLo) + 1
+}
+
+// Represents a generator that is constructed lazily upon first use.
+type lazyGenerator struct {
+	once    sync.Once
+	g       *Generator
+	newFunc func() *Generator
+}
+
+// Returns the generator, constructing it upon first call.
+func (l *lazyGenerator) get() *Generator {
+	l.once.Do(func() {
+		l.g = l.newFunc()
+	})
+	return l.g
+}
+
+// The global generator, which is initialized upon first use so that importing
+// this package does not touch crypto/rand.
+var globalGenerator = &lazyGenerator{newFunc: func() *Generator {
+	return NewGenerator()
+}}
 
 // Generates a new SCRU128 ID object using the global generator, or panics if
 // crypto/rand fails.
 //
 // This function is thread-safe; multiple threads can call it concurrently.
 func New() Id {
-	id, err := globalGenerator.Generate()
+	id, err := globalGenerator.get().Generate()
 	if err != nil {
 		panic(err)
 	}
 	return id
 }
 
-// Generates a new SCRU128 ID encoded in the 26-digit canonical string
+// Generates a new SCRU128 ID encoded in the 25-digit canonical string
 // representation using the global generator, or panics if crypto/rand fails.
 //
 // This function is thread-safe. Use this to quickly get a new SCRU128 ID as a
@@ -47,3 +97,63 @@ func New() Id {
 func NewString() string {
 	return New().String()
 }
+
+// Generates a new SCRU128 ID using the global generator and appends its 25-digit
+// canonical string representation to `dst`.
+//
+// This function is thread-safe and, unlike [NewString], does not allocate a
+// new string if `dst` has enough capacity, which helps hot paths such as
+// request handlers reuse a buffer. Unlike [New], this function returns a
+// non-nil err instead of panicking if crypto/rand fails.
+func AppendNewString(dst []byte) ([]byte, error) {
+	id, err := globalGenerator.get().Generate()
+	if err != nil {
+		return dst, err
+	}
+	return id.AppendText(dst)
+}
+
+// Generates `n` new SCRU128 ID objects using the global generator, acquiring
+// the lock of the generator only once.
+//
+// This function is thread-safe and returns monotonically increasing IDs unless
+// the generator is reset upon significant clock rollback in the middle of the
+// batch. Unlike [New], this function returns a non-nil err instead of
+// panicking if crypto/rand fails.
+//
+// This function panics if `n` is negative.
+func NewN(n int) ([]Id, error) {
+	if n < 0 {
+		panic("`n` must not be negative")
+	}
+	g := globalGenerator.get()
+	g.acquire()
+	defer g.release()
+	ids := make([]Id, n)
+	for i := range ids {
+		id, err := g.GenerateOrResetCore(g.now(), defaultRollbackAllowance)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// Whether [Id.String] returns uppercase letters.
+var upperString atomic.Bool
+
+// Sets whether [Id.String], and hence [NewString] and the fmt package verbs
+// such as %v, return uppercase letters instead of the canonical lowercase
+// letters (the default), for systems standardized on uppercase IDs.
+//
+// This setting is global and affects all callers in the process, including
+// other libraries that use this package. Although it is safe for concurrent
+// use, set it once at initialization, e.g., in an init function or at the
+// beginning of main, because changing it later leads to inconsistent outputs.
+// The other encodings such as [Id.MarshalText], [Id.AppendText],
+// [Id.MarshalJSON], and [Normalize] always produce the canonical lowercase
+// form, and parsing functions accept both cases regardless of this setting.
+func SetStringCase(upper bool) {
+	upperString.Store(upper)
+}
@@ -1,9 +1,12 @@
 package scru128
 
 import (
+	crand "crypto/rand"
 	"fmt"
+	"io"
 	"math"
 	"regexp"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -108,9 +111,160 @@ func TestThreading(t *testing.T) {
 	<-done
 }
 
+// Switches case of String() and NewString() globally
+func TestSetStringCase(t *testing.T) {
+	SetStringCase(true)
+	defer SetStringCase(false)
+
+	re := regexp.MustCompile(`^[0-9A-Z]{25}$`)
+	for i := 0; i < 1_000; i++ {
+		s := NewString()
+		if !re.MatchString(s) {
+			t.Fail()
+		}
+		x, err := Parse(s)
+		if err != nil || x.String() != s {
+			t.Fail()
+		}
+		if y, err := Parse(strings.ToLower(s)); err != nil || y != x {
+			t.Fail()
+		}
+		if text, _ := x.MarshalText(); string(text) != strings.ToLower(s) {
+			t.Fail()
+		}
+		if n, _ := Normalize(s); n != strings.ToLower(s) {
+			t.Fail()
+		}
+	}
+
+	SetStringCase(false)
+	if s := NewString(); !regexp.MustCompile(`^[0-9a-z]{25}$`).MatchString(s) {
+		t.Fail()
+	}
+}
+
+// Generates batch of unique and increasing IDs
+func TestNewN(t *testing.T) {
+	for _, n := range []int{0, 1, 10_000} {
+		ids, err := NewN(n)
+		if err != nil || len(ids) != n {
+			t.Fail()
+		}
+		set := NewIdSet(ids...)
+		if set.Len() != n {
+			t.Fail()
+		}
+		for i := 1; i < len(ids); i++ {
+			if ids[i-1].Cmp(ids[i]) >= 0 {
+				t.Fail()
+			}
+		}
+	}
+
+	// interleaves with concurrent callers
+	group := new(sync.WaitGroup)
+	results := make([][]Id, 4)
+	for i := range results {
+		group.Add(1)
+		go func(i int) {
+			defer group.Done()
+			results[i], _ = NewN(1_000)
+		}(i)
+	}
+	group.Wait()
+	set := new(IdSet)
+	for _, ids := range results {
+		for _, e := range ids {
+			set.Add(e)
+		}
+	}
+	if set.Len() != 4*1_000 {
+		t.Fail()
+	}
+}
+
+// Appends new ID without allocating string
+func TestAppendNewString(t *testing.T) {
+	re := regexp.MustCompile(`^prefix:[0-9a-z]{25}$`)
+	buffer := make([]byte, 0, 64)
+	prev := ""
+	for i := 0; i < 1_000; i++ {
+		buffer = append(buffer[:0], "prefix:"...)
+		buffer, err := AppendNewString(buffer)
+		if err != nil || !re.Match(buffer) || string(buffer[7:]) <= prev {
+			t.Fail()
+		}
+		prev = string(buffer[7:])
+	}
+
+	// allocates nothing beyond what generation itself does
+	baseline := testing.AllocsPerRun(100, func() {
+		globalGenerator.get().Generate()
+	})
+	allocs := testing.AllocsPerRun(100, func() {
+		buffer, _ = AppendNewString(buffer[:0])
+	})
+	if allocs > baseline {
+		t.Fail()
+	}
+}
+
+// Wraps a reader to count read calls
+type countingReader struct {
+	r     io.Reader
+	count int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	r.count++
+	return r.r.Read(p)
+}
+
+// Constructs global generator lazily and reads no random number until first use
+func TestLazyGlobalGenerator(t *testing.T) {
+	rng := &countingReader{r: crand.Reader}
+	calls := 0
+	l := &lazyGenerator{newFunc: func() *Generator {
+		calls++
+		return NewGeneratorWithRng(rng)
+	}}
+	if calls != 0 || rng.count != 0 || l.g != nil {
+		t.Fail()
+	}
+
+	g := l.get()
+	if calls != 1 || rng.count != 0 || g == nil || l.get() != g {
+		t.Fail()
+	}
+
+	g.Generate()
+	if calls != 1 || rng.count == 0 {
+		t.Fail()
+	}
+}
+
 func BenchmarkNewString(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		NewString()
 	}
 }
+
+func BenchmarkNewStringParallel(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			NewString()
+		}
+	})
+}
+
+func BenchmarkAppendNewStringParallel(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		buffer := make([]byte, 0, 25)
+		for pb.Next() {
+			buffer, _ = AppendNewString(buffer[:0])
+		}
+	})
+}
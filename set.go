@@ -0,0 +1,86 @@
+package scru128
+
+import "sort"
+
+// Represents a set of SCRU128 IDs that deduplicates IDs and lists them in the
+// ascending order.
+//
+// The set is backed by a map, so [IdSet.Add], [IdSet.Contains], and
+// [IdSet.Remove] run in O(1) time on average, while [IdSet.Sorted] sorts the
+// elements in O(n log n) time on every call. The zero value is an empty set
+// ready to use.
+//
+// This type is NOT thread-safe.
+type IdSet struct {
+	m map[Id]struct{}
+}
+
+// Creates a set object that contains `ids`.
+func NewIdSet(ids ...Id) *IdSet {
+	s := &IdSet{m: make(map[Id]struct{}, len(ids))}
+	for _, e := range ids {
+		s.m[e] = struct{}{}
+	}
+	return s
+}
+
+// Adds `id` to the set, returning true if the set did not contain `id` yet.
+//
+// This method runs in O(1) time on average.
+func (s *IdSet) Add(id Id) bool {
+	if _, ok := s.m[id]; ok {
+		return false
+	}
+	if s.m == nil {
+		s.m = make(map[Id]struct{})
+	}
+	s.m[id] = struct{}{}
+	return true
+}
+
+// Returns true if the set contains `id`.
+//
+// This method runs in O(1) time on average.
+func (s *IdSet) Contains(id Id) bool {
+	_, ok := s.m[id]
+	return ok
+}
+
+// Removes `id` from the set, returning true if the set contained `id`.
+//
+// This method runs in O(1) time on average.
+func (s *IdSet) Remove(id Id) bool {
+	if _, ok := s.m[id]; !ok {
+		return false
+	}
+	delete(s.m, id)
+	return true
+}
+
+// Returns the number of IDs in the set.
+func (s *IdSet) Len() int {
+	return len(s.m)
+}
+
+// Returns a new slice of the IDs in the set sorted in the ascending order.
+//
+// This method runs in O(n log n) time, where n is the number of IDs in the set,
+// because the set does not maintain the order of IDs internally.
+func (s *IdSet) Sorted() []Id {
+	ids := make([]Id, 0, len(s.m))
+	for e := range s.m {
+		ids = append(ids, e)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].CmpPtr(&ids[j]) < 0 })
+	return ids
+}
+
+// Returns a set of the keys returned by [Id.MapKey] of `ids`, with duplicates
+// removed.
+func SliceToStringSet(ids []Id) map[string]struct{} {
+	m := make(map[string]struct{}, len(ids))
+	for _, e := range ids {
+		m[e.MapKey()] = struct{}{}
+	}
+	return m
+}
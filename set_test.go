@@ -0,0 +1,92 @@
+package scru128
+
+import (
+	"strings"
+	"testing"
+)
+
+// Deduplicates IDs and lists them in ascending order
+func TestIdSet(t *testing.T) {
+	var s IdSet
+	if s.Len() != 0 || s.Contains(Nil) || s.Remove(Nil) || len(s.Sorted()) != 0 {
+		t.Fail()
+	}
+
+	g := NewGenerator()
+	ids := make([]Id, 1_000)
+	for i := range ids {
+		ids[i], _ = g.Generate()
+	}
+	for i := len(ids) - 1; i >= 0; i-- {
+		if !s.Add(ids[i]) || s.Add(ids[i]) {
+			t.Fail()
+		}
+	}
+	for _, e := range ids {
+		if !s.Contains(e) {
+			t.Fail()
+		}
+	}
+
+	sorted := s.Sorted()
+	if s.Len() != len(ids) || len(sorted) != len(ids) {
+		t.Fail()
+	}
+	for i, e := range sorted {
+		if e != ids[i] {
+			t.Fail()
+		}
+	}
+
+	for i := 0; i < len(ids); i += 2 {
+		if !s.Remove(ids[i]) || s.Remove(ids[i]) || s.Contains(ids[i]) {
+			t.Fail()
+		}
+	}
+	sorted = s.Sorted()
+	if s.Len() != len(ids)/2 || len(sorted) != len(ids)/2 {
+		t.Fail()
+	}
+	for i, e := range sorted {
+		if e != ids[2*i+1] {
+			t.Fail()
+		}
+	}
+
+	u := NewIdSet(ids[1], ids[0], ids[1])
+	if u.Len() != 2 || u.Sorted()[0] != ids[0] || u.Sorted()[1] != ids[1] {
+		t.Fail()
+	}
+}
+
+// Deduplicates IDs into set of canonical string keys
+func TestSliceToStringSet(t *testing.T) {
+	if m := SliceToStringSet(nil); m == nil || len(m) != 0 {
+		t.Fail()
+	}
+
+	g := NewGenerator()
+	ids := make([]Id, 0, 2_000)
+	for i := 0; i < 1_000; i++ {
+		x, _ := g.Generate()
+		ids = append(ids, x)
+	}
+	ids = append(ids, ids[:500]...)
+	ids = append(ids, ids[250:750]...)
+
+	m := SliceToStringSet(ids)
+	if len(m) != 1_000 {
+		t.Fail()
+	}
+	for _, e := range ids {
+		if _, ok := m[e.MapKey()]; !ok || e.MapKey() != e.String() {
+			t.Fail()
+		}
+	}
+
+	SetStringCase(true)
+	defer SetStringCase(false)
+	if x := ids[0]; x.MapKey() != strings.ToLower(x.String()) {
+		t.Fail()
+	}
+}
@@ -0,0 +1,65 @@
+package scru128
+
+import (
+	"sync"
+	"time"
+)
+
+// Represents a wrapper of [Generator] that ensures the timestamps of successive
+// IDs advance by at least a configured number of milliseconds, sleeping if
+// necessary.
+//
+// This type intentionally reduces the throughput of ID generation to at most
+// one ID per interval. It is useful to rate-limit downstream systems that key
+// on the timestamp field of IDs.
+//
+// This structure must be instantiated by [NewThrottledGenerator].
+type ThrottledGenerator struct {
+	base        *Generator
+	minInterval uint64
+
+	// The timestamp of the immediately preceding ID.
+	timestamp uint64
+
+	lock sync.Mutex
+}
+
+// Creates a throttled generator object that wraps `base` and ensures the
+// timestamps of successive IDs differ by at least `minIntervalMs`
+// milliseconds.
+//
+// The guarantee holds only if `base` is not used for other purposes, because
+// IDs generated directly by `base` may push its internal timestamp ahead.
+//
+// This constructor panics if `base` is nil.
+func NewThrottledGenerator(base *Generator, minIntervalMs uint64) *ThrottledGenerator {
+	if base == nil {
+		panic("constructor called with nil `base`")
+	} else if minIntervalMs > maxTimestamp {
+		panic("`minIntervalMs` out of reasonable range")
+	}
+	return &ThrottledGenerator{base: base, minInterval: minIntervalMs}
+}
+
+// Generates a new SCRU128 ID object using [Generator.Generate] of the base
+// generator, sleeping first until the configured interval has elapsed since
+// the timestamp of the immediately preceding ID.
+//
+// This method is thread-safe; concurrent calls are serialized and each waits
+// for its own interval.
+//
+// This method returns a non-nil err if the random number generator fails.
+func (g *ThrottledGenerator) Generate() (id Id, err error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	if g.timestamp > 0 {
+		next := time.UnixMilli(int64(g.base.epoch + g.timestamp + g.minInterval))
+		time.Sleep(time.Until(next))
+	}
+
+	id, err = g.base.Generate()
+	if err == nil {
+		g.timestamp = id.Timestamp()
+	}
+	return
+}
@@ -0,0 +1,22 @@
+package scru128
+
+import "testing"
+
+// Generates IDs whose timestamps differ at least by configured interval
+func TestThrottledGenerator(t *testing.T) {
+	for _, interval := range []uint64{0, 1, 5, 20} {
+		g := NewThrottledGenerator(NewGenerator(), interval)
+		prev, err := g.Generate()
+		if err != nil {
+			t.Fail()
+		}
+		for i := 0; i < 10; i++ {
+			curr, err := g.Generate()
+			if err != nil || prev.Cmp(curr) >= 0 ||
+				curr.Timestamp()-prev.Timestamp() < interval {
+				t.Fail()
+			}
+			prev = curr
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package scru128
+
+import "math/bits"
+
+// Represents a 128-bit unsigned integer as a pair of the upper and lower 64
+// bits, which helps arithmetic on IDs, e.g., to compute how many possible IDs
+// lie between two IDs for sharding, without math/big.
+type Uint128 struct {
+	Hi, Lo uint64
+}
+
+// Returns the 128-bit unsigned integer representation of the object.
+func (bs Id) ToUint128() Uint128 {
+	hi, lo := bs.Uint64Pair()
+	return Uint128{hi, lo}
+}
+
+// Creates a SCRU128 ID object from a 128-bit unsigned integer.
+//
+// This is the inverse of [Id.ToUint128].
+func FromUint128(u Uint128) Id {
+	return FromUint64Pair(u.Hi, u.Lo)
+}
+
+// Returns the sum of the object and the argument, wrapping around upon
+// overflow.
+func (u Uint128) Add(v Uint128) Uint128 {
+	lo, carry := bits.Add64(u.Lo, v.Lo, 0)
+	hi, _ := bits.Add64(u.Hi, v.Hi, carry)
+	return Uint128{hi, lo}
+}
+
+// Returns the difference of the object and the argument, wrapping around upon
+// underflow.
+func (u Uint128) Sub(v Uint128) Uint128 {
+	lo, borrow := bits.Sub64(u.Lo, v.Lo, 0)
+	hi, _ := bits.Sub64(u.Hi, v.Hi, borrow)
+	return Uint128{hi, lo}
+}
+
+// Returns -1, 0, or 1 if the object is less than, equal to, or greater than the
+// argument, respectively.
+func (u Uint128) Cmp(v Uint128) int {
+	if u.Hi != v.Hi {
+		if u.Hi < v.Hi {
+			return -1
+		}
+		return 1
+	} else if u.Lo != v.Lo {
+		if u.Lo < v.Lo {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
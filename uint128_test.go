@@ -0,0 +1,43 @@
+package scru128
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// Converts to math/big value for comparison
+func (u Uint128) bigInt() *big.Int {
+	x := new(big.Int).SetUint64(u.Hi)
+	return x.Lsh(x, 64).Or(x, new(big.Int).SetUint64(u.Lo))
+}
+
+// Performs arithmetic consistent with math/big
+func TestUint128(t *testing.T) {
+	modulus := new(big.Int).Lsh(big.NewInt(1), 128)
+	cases := []Uint128{{0, 0}, {0, 1}, {0, 1<<64 - 1}, {1, 0}, {1<<64 - 1, 1<<64 - 1}}
+	for i := 0; i < 1_000; i++ {
+		cases = append(cases, Uint128{rand.Uint64(), rand.Uint64()})
+	}
+
+	for i, u := range cases {
+		if u.Cmp(u) != 0 || FromUint128(u).ToUint128() != u ||
+			FromUint128(u).Cmp(FromUint64Pair(u.Hi, u.Lo)) != 0 {
+			t.Fail()
+		}
+
+		v := cases[(i*7+3)%len(cases)]
+		sum := new(big.Int).Add(u.bigInt(), v.bigInt())
+		if u.Add(v).bigInt().Cmp(sum.Mod(sum, modulus)) != 0 {
+			t.Errorf("%v + %v", u, v)
+		}
+		diff := new(big.Int).Sub(u.bigInt(), v.bigInt())
+		if u.Sub(v).bigInt().Cmp(diff.Mod(diff, modulus)) != 0 {
+			t.Errorf("%v - %v", u, v)
+		}
+		if u.Cmp(v) != u.bigInt().Cmp(v.bigInt()) ||
+			u.Cmp(v) != FromUint128(u).Cmp(FromUint128(v)) {
+			t.Errorf("%v <=> %v", u, v)
+		}
+	}
+}